@@ -42,6 +42,25 @@ func (ap *AstPrinter) visitCallExpr(expr *Call) (interface{}, error) {
 	return callee + "(args " + strings.Join(args, "))"), nil
 }
 
+func (ap *AstPrinter) visitComparisonExpr(expr *Comparison) (interface{}, error) {
+	buf := bytes.Buffer{}
+	buf.WriteString("(compare")
+	for k, operand := range expr.Operands {
+		if k > 0 {
+			buf.WriteString(" " + expr.Operators[k-1].Lexeme)
+		}
+		s, _ := operand.Accept(ap)
+		buf.WriteString(" " + s.(string))
+	}
+	buf.WriteString(")")
+
+	return buf.String(), nil
+}
+
+func (ap *AstPrinter) visitConditionalExpr(expr *Conditional) (interface{}, error) {
+	return ap.parenthesizeExpr("if-else", expr.Condition, expr.ThenBranch, expr.ElseBranch)
+}
+
 func (ap *AstPrinter) visitGetExpr(expr *Get) (interface{}, error) {
 	object, err := ap.parenthesizeExpr("object", expr.Object)
 	if err != nil {
@@ -55,6 +74,26 @@ func (ap *AstPrinter) visitGroupingExpr(expr *Grouping) (interface{}, error) {
 	return ap.parenthesizeExpr("group", expr.Expression)
 }
 
+func (ap *AstPrinter) visitIndexExpr(expr *Index) (interface{}, error) {
+	return ap.parenthesizeExpr("index", expr.Object, expr.Index)
+}
+
+func (ap *AstPrinter) visitInterpolationExpr(expr *Interpolation) (interface{}, error) {
+	return ap.parenthesizeExpr("fstring", expr.Parts...)
+}
+
+func (ap *AstPrinter) visitKeywordArgExpr(expr *KeywordArg) (interface{}, error) {
+	return ap.parenthesizeExpr("keyword "+expr.Name.Lexeme, expr.Value)
+}
+
+func (ap *AstPrinter) visitLambdaExpr(expr *Lambda) (interface{}, error) {
+	return ap.function("lambda", expr.Function)
+}
+
+func (ap *AstPrinter) visitListExpr(expr *List) (interface{}, error) {
+	return ap.parenthesizeExpr("list", expr.Elements...)
+}
+
 func (ap *AstPrinter) visitLiteralExpr(expr *Literal) (interface{}, error) {
 	if expr.Value == nil {
 		return "nil", nil
@@ -66,6 +105,16 @@ func (ap *AstPrinter) visitLogicalExpr(expr *Logical) (interface{}, error) {
 	return ap.parenthesizeExpr(expr.Operator.Lexeme, expr.Left, expr.Right)
 }
 
+func (ap *AstPrinter) visitMapExpr(expr *Map) (interface{}, error) {
+	entries := []string{"map"}
+	for k := range expr.Keys {
+		entry, _ := ap.parenthesizeExpr("entry", expr.Keys[k], expr.Values[k])
+		entries = append(entries, entry)
+	}
+
+	return "(" + strings.Join(entries, " ") + ")", nil
+}
+
 func (ap *AstPrinter) visitSetExpr(expr *Set) (interface{}, error) {
 	object, err := ap.parenthesizeExpr("object", expr.Object)
 	if err != nil {
@@ -80,6 +129,25 @@ func (ap *AstPrinter) visitSetExpr(expr *Set) (interface{}, error) {
 	return "(set " + object + "(name " + expr.Name.Lexeme + ")" + value + ")", nil
 }
 
+func (ap *AstPrinter) visitSetIndexExpr(expr *SetIndex) (interface{}, error) {
+	return ap.parenthesizeExpr("set-index", expr.Object, expr.Index, expr.Value)
+}
+
+func (ap *AstPrinter) visitSliceExpr(expr *Slice) (interface{}, error) {
+	start, stop := Expr(NewLiteral(nil)), Expr(NewLiteral(nil))
+	if expr.Start != nil {
+		start = expr.Start
+	}
+	if expr.Stop != nil {
+		stop = expr.Stop
+	}
+	return ap.parenthesizeExpr("slice", expr.Object, start, stop)
+}
+
+func (ap *AstPrinter) visitSpreadExpr(expr *Spread) (interface{}, error) {
+	return ap.parenthesizeExpr("spread", expr.Value)
+}
+
 func (ap *AstPrinter) visitSuperExpr(expr *Super) (interface{}, error) {
 	return "(super " + expr.Keyword.Lexeme + " " + expr.Method.Lexeme + ")", nil
 }
@@ -112,6 +180,10 @@ func (ap *AstPrinter) visitBlockStmt(b *Block) (interface{}, error) {
 	return "(block " + strings.Join(body, " ") + ")", nil
 }
 
+func (ap *AstPrinter) visitBreakStmt(b *Break) (interface{}, error) {
+	return "(break)", nil
+}
+
 func (ap *AstPrinter) visitClassStmt(c *Class) (interface{}, error) {
 	fns := make([]string, 0)
 	for _, method := range c.Methods {
@@ -125,15 +197,69 @@ func (ap *AstPrinter) visitClassStmt(c *Class) (interface{}, error) {
 	return "(class " + c.Name.Lexeme + " " + strings.Join(fns, " ") + ")", nil
 }
 
+func (ap *AstPrinter) visitContinueStmt(c *Continue) (interface{}, error) {
+	return "(continue)", nil
+}
+
+func (ap *AstPrinter) visitExceptStmt(e *Except) (interface{}, error) {
+	buf := bytes.Buffer{}
+	buf.WriteString("(except")
+	if e.Typ != nil {
+		typ, err := e.Typ.Accept(ap)
+		if err != nil {
+			return "", err
+		}
+		buf.WriteString(" " + typ.(string))
+	}
+	if e.Name != nil {
+		buf.WriteString(" (as " + e.Name.Lexeme + ")")
+	}
+	body, err := ap.parenthesizeStmt("body", e.Body)
+	if err != nil {
+		return "", err
+	}
+	buf.WriteString(" " + body + ")")
+
+	return buf.String(), nil
+}
+
 func (ap *AstPrinter) visitExpressionStmt(e *Expression) (interface{}, error) {
 	return e.Expression.Accept(ap)
 }
 
+func (ap *AstPrinter) visitForInStmt(f *ForIn) (interface{}, error) {
+	iterable, err := ap.parenthesizeExpr("in", f.Iterable)
+	if err != nil {
+		return "", err
+	}
+	body, err := ap.parenthesizeStmt("body", f.Body)
+	if err != nil {
+		return "", err
+	}
+
+	return "(for " + f.Name.Lexeme + " " + iterable + " " + body + ")", nil
+}
+
 func (ap *AstPrinter) visitFunctionStmt(f *Function) (interface{}, error) {
+	return ap.function("function "+f.Name.Lexeme, f)
+}
+
+func (ap *AstPrinter) function(name string, f *Function) (string, error) {
 	params := make([]string, 0)
-	for _, v := range f.Params {
+	for k, v := range f.Params {
+		if k < len(f.Defaults) && f.Defaults[k] != nil {
+			value, err := f.Defaults[k].Accept(ap)
+			if err != nil {
+				return "", err
+			}
+			params = append(params, v.Lexeme+" = "+value.(string))
+			continue
+		}
 		params = append(params, v.Lexeme)
 	}
+	if f.Rest != nil {
+		params = append(params, "*"+f.Rest.Lexeme)
+	}
 	stmts := make([]string, 0)
 	for _, stmt := range f.Body {
 		s, err := stmt.Accept(ap)
@@ -143,7 +269,7 @@ func (ap *AstPrinter) visitFunctionStmt(f *Function) (interface{}, error) {
 		stmts = append(stmts, "("+s.(string)+")")
 	}
 
-	return "(function " + f.Name.Lexeme + " (args (" + strings.Join(params, ", ") + ")) (body " + strings.Join(stmts, " ") + "))", nil
+	return "(" + name + " (args (" + strings.Join(params, ", ") + ")) (body " + strings.Join(stmts, " ") + "))", nil
 }
 
 func (ap *AstPrinter) visitIfStmt(i *If) (interface{}, error) {
@@ -166,10 +292,18 @@ func (ap *AstPrinter) visitIfStmt(i *If) (interface{}, error) {
 	return "(if " + cond + " " + thenBranch + " " + elseBranch + ")", nil
 }
 
+func (ap *AstPrinter) visitImportStmt(i *Import) (interface{}, error) {
+	return "(import " + i.Path.Lexeme + " (as " + i.Name.Lexeme + "))", nil
+}
+
 func (ap *AstPrinter) visitIncludeStmt(i *Include) (interface{}, error) {
 	return "(include " + i.Path.Lexeme + ")", nil
 }
 
+func (ap *AstPrinter) visitRaiseStmt(r *Raise) (interface{}, error) {
+	return ap.parenthesizeExpr(r.Keyword.Lexeme, r.Value)
+}
+
 func (ap *AstPrinter) visitReturnStmt(r *Return) (interface{}, error) {
 	expr, err := ap.parenthesizeExpr(r.Keyword.Lexeme, r.Value)
 	if err != nil {
@@ -179,6 +313,25 @@ func (ap *AstPrinter) visitReturnStmt(r *Return) (interface{}, error) {
 	return expr, nil
 }
 
+func (ap *AstPrinter) visitTryStmt(t *Try) (interface{}, error) {
+	stmts := []Stmt{t.Body}
+	for _, handler := range t.Handlers {
+		stmts = append(stmts, handler)
+	}
+	if t.FinallyBranch != nil {
+		stmts = append(stmts, t.FinallyBranch)
+	}
+
+	return ap.parenthesizeStmt("try", stmts...)
+}
+
+func (ap *AstPrinter) visitYieldStmt(y *Yield) (interface{}, error) {
+	if y.Value == nil {
+		return "(yield)", nil
+	}
+	return ap.parenthesizeExpr("yield", y.Value)
+}
+
 func (ap *AstPrinter) visitWhileStmt(p *While) (interface{}, error) {
 	cond, err := ap.parenthesizeExpr("cond", p.Condition)
 	if err != nil {
@@ -188,6 +341,13 @@ func (ap *AstPrinter) visitWhileStmt(p *While) (interface{}, error) {
 	if err != nil {
 		return "", nil
 	}
+	if p.Increment != nil {
+		increment, err := ap.parenthesizeExpr("increment", p.Increment)
+		if err != nil {
+			return "", err
+		}
+		return "(while " + cond + " " + body + " " + increment + ")", nil
+	}
 	return "(while " + cond + " " + body + ")", nil
 }
 
@@ -41,6 +41,34 @@ func TestAstPrinter(t *testing.T) {
 				),
 			},
 		},
+		{
+			name:     "chained comparison: 0 <= x < 10",
+			expected: "(compare 0 <= (variable x) < 10)",
+			given: []tlps.Stmt{
+				tlps.NewExpression(tlps.NewComparison(
+					[]tlps.Expr{
+						tlps.NewLiteral(0),
+						tlps.NewVariable(tlps.NewToken(tlps.IdentifierTT, "x", nil, 1)),
+						tlps.NewLiteral(10),
+					},
+					[]*tlps.Token{
+						tlps.NewToken(tlps.LessEqualTT, "<=", nil, 1),
+						tlps.NewToken(tlps.LessTT, "<", nil, 1),
+					},
+				)),
+			},
+		},
+		{
+			name:     "conditional: 1 if x else 2",
+			expected: "(if-else (variable x) 1 2)",
+			given: []tlps.Stmt{
+				tlps.NewExpression(tlps.NewConditional(
+					tlps.NewVariable(tlps.NewToken(tlps.IdentifierTT, "x", nil, 1)),
+					tlps.NewLiteral(1),
+					tlps.NewLiteral(2),
+				)),
+			},
+		},
 		{
 			name:     "assign: x = 123",
 			expected: "(assign x 123)",
@@ -60,6 +88,26 @@ func TestAstPrinter(t *testing.T) {
 				)),
 			},
 		},
+		{
+			name:     "set index: xs[0] = [1, 2]",
+			expected: "(set-index (variable xs) 0 (list 1 2))",
+			given: []tlps.Stmt{
+				tlps.NewExpression(tlps.NewSetIndex(
+					tlps.NewVariable(
+						tlps.NewToken(tlps.IdentifierTT, "xs", nil, 1),
+					),
+					tlps.NewToken(tlps.LeftBracketTT, "[", nil, 1),
+					tlps.NewLiteral(0),
+					tlps.NewList(
+						tlps.NewToken(tlps.LeftBracketTT, "[", nil, 1),
+						[]tlps.Expr{
+							tlps.NewLiteral(1),
+							tlps.NewLiteral(2),
+						},
+					),
+				)),
+			},
+		},
 		{
 			name:     "if statement",
 			expected: "(if (cond true) (thenBranch (variable x)) (elseBranch (variable y)))",
@@ -96,6 +144,37 @@ func TestAstPrinter(t *testing.T) {
 							},
 						),
 					),
+					nil,
+				),
+			},
+		},
+		{
+			name:     "for-in statement",
+			expected: "(for x (in (variable xs)) (body (variable x)))",
+			given: []tlps.Stmt{
+				tlps.NewForIn(
+					tlps.NewToken(tlps.IdentifierTT, "x", nil, 1),
+					tlps.NewToken(tlps.InTT, "in", nil, 1),
+					tlps.NewVariable(tlps.NewToken(tlps.IdentifierTT, "xs", nil, 1)),
+					tlps.NewExpression(tlps.NewVariable(tlps.NewToken(tlps.IdentifierTT, "x", nil, 2))),
+				),
+			},
+		},
+		{
+			name:     "yield statement",
+			expected: "(yield 1)",
+			given: []tlps.Stmt{
+				tlps.NewYield(tlps.NewToken(tlps.YieldTT, "yield", nil, 1), tlps.NewLiteral(1)),
+			},
+		},
+		{
+			name:     "import statement",
+			expected: `(import "lib.tlps" (as lib))`,
+			given: []tlps.Stmt{
+				tlps.NewImport(
+					tlps.NewToken(tlps.ImportTT, "import", nil, 1),
+					tlps.NewToken(tlps.StringTT, `"lib.tlps"`, "lib.tlps", 1),
+					tlps.NewToken(tlps.IdentifierTT, "lib", nil, 1),
 				),
 			},
 		},
@@ -119,7 +198,7 @@ func TestAstPrinter(t *testing.T) {
 						tlps.NewExpression(
 							tlps.NewLiteral(987)),
 					},
-					tlps.NewToken(tlps.LeftBraceTT, "{", nil, 1),
+					tlps.NewToken(tlps.IndentTT, "<indent>", nil, 1),
 					tlps.NoneBlock,
 				),
 			},
@@ -134,6 +213,8 @@ func TestAstPrinter(t *testing.T) {
 						tlps.NewToken(tlps.IdentifierTT, "x", nil, 1),
 						tlps.NewToken(tlps.IdentifierTT, "y", nil, 1),
 					},
+					nil,
+					nil,
 					[]tlps.Stmt{
 						tlps.NewExpression(tlps.NewLiteral(1)),
 						tlps.NewExpression(tlps.NewLiteral(2)),
@@ -141,6 +222,53 @@ func TestAstPrinter(t *testing.T) {
 				),
 			},
 		},
+		{
+			name:     "lambda: fun (x): x",
+			expected: "(lambda (args (x)) (body ((return (variable x)))))",
+			given: []tlps.Stmt{
+				tlps.NewExpression(tlps.NewLambda(
+					tlps.NewFunction(
+						tlps.NewToken(tlps.IdentifierTT, "<lambda>", nil, 1),
+						[]*tlps.Token{
+							tlps.NewToken(tlps.IdentifierTT, "x", nil, 1),
+						},
+						nil,
+						nil,
+						[]tlps.Stmt{
+							tlps.NewReturn(
+								tlps.NewToken(tlps.ReturnTT, "return", nil, 1),
+								tlps.NewVariable(tlps.NewToken(tlps.IdentifierTT, "x", nil, 1)),
+							),
+						},
+					).(*tlps.Function),
+				)),
+			},
+		},
+		{
+			name:     "slice: xs[1:]",
+			expected: "(slice (variable xs) 1 nil)",
+			given: []tlps.Stmt{
+				tlps.NewExpression(tlps.NewSlice(
+					tlps.NewVariable(tlps.NewToken(tlps.IdentifierTT, "xs", nil, 1)),
+					tlps.NewToken(tlps.LeftBracketTT, "[", nil, 1),
+					tlps.NewLiteral(1),
+					nil,
+				)),
+			},
+		},
+		{
+			name:     "f-string: f\"x = {x}\"",
+			expected: "(fstring x =  (variable x))",
+			given: []tlps.Stmt{
+				tlps.NewExpression(tlps.NewInterpolation(
+					tlps.NewToken(tlps.FStringStartTT, "f\"", nil, 1),
+					[]tlps.Expr{
+						tlps.NewLiteral("x = "),
+						tlps.NewVariable(tlps.NewToken(tlps.IdentifierTT, "x", nil, 1)),
+					},
+				)),
+			},
+		},
 		{
 			name:     "class",
 			expected: "(class Hoge (function init (args (x)) (body ((set (object (this))(name x)(value (variable x)))))))",
@@ -157,6 +285,8 @@ func TestAstPrinter(t *testing.T) {
 							[]*tlps.Token{
 								tlps.NewToken(tlps.IdentifierTT, "x", nil, 2),
 							},
+							nil,
+							nil,
 							[]tlps.Stmt{
 								tlps.NewExpression(
 									tlps.NewSet(
@@ -6,5 +6,6 @@ const (
 	NoneBlock BlockType = iota
 	ForBlock
 	IfBlock
+	TryBlock
 	WhileBlock
 )
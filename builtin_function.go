@@ -0,0 +1,39 @@
+package tlps
+
+// BuiltinFunction is struct of builtin function which needs interpreter such as iter
+type BuiltinFunction struct {
+	name     string
+	min      int
+	max      int
+	function func(*Interpreter, []interface{}) (interface{}, error)
+}
+
+// NewBuiltinFunction is constructor of BuiltinFunction.
+// min and max are the numbers of arguments which the function accepts.
+// max -1 means that the function accepts any number of arguments.
+func NewBuiltinFunction(name string, min int, max int, function func(*Interpreter, []interface{}) (interface{}, error)) *BuiltinFunction {
+	return &BuiltinFunction{
+		name:     name,
+		min:      min,
+		max:      max,
+		function: function,
+	}
+}
+
+// Call calls builtin function
+func (bf *BuiltinFunction) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	callStack := i.Runtime.CallStack
+	callStack.Push(NewCallFrame(bf.name, "<builtin>", 0))
+	defer callStack.Pop()
+
+	return bf.function(i, arguments)
+}
+
+// Arity returns arity of builtin function
+func (bf *BuiltinFunction) Arity() (int, int) {
+	return bf.min, bf.max
+}
+
+func (bf *BuiltinFunction) String() string {
+	return "<native fn " + bf.name + ">"
+}
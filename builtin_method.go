@@ -0,0 +1,39 @@
+package tlps
+
+// BuiltinMethod is struct of method of builtin type such as list
+type BuiltinMethod struct {
+	name     string
+	arity    int
+	function func([]interface{}) (interface{}, error)
+}
+
+// NewBuiltinMethod is constructor of BuiltinMethod.
+// arity -1 means that the method accepts any number of arguments.
+func NewBuiltinMethod(name string, arity int, function func([]interface{}) (interface{}, error)) *BuiltinMethod {
+	return &BuiltinMethod{
+		name:     name,
+		arity:    arity,
+		function: function,
+	}
+}
+
+// Call calls builtin method
+func (bm *BuiltinMethod) Call(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	callStack := i.Runtime.CallStack
+	callStack.Push(NewCallFrame(bm.name, "<builtin>", 0))
+	defer callStack.Pop()
+
+	return bm.function(arguments)
+}
+
+// Arity returns arity of builtin method
+func (bm *BuiltinMethod) Arity() (int, int) {
+	if bm.arity == -1 {
+		return 0, -1
+	}
+	return bm.arity, bm.arity
+}
+
+func (bm *BuiltinMethod) String() string {
+	return "<builtin method " + bm.name + ">"
+}
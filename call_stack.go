@@ -0,0 +1,138 @@
+package tlps
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/goropikari/tlps/collections/stack"
+)
+
+// maxCallDepth is the limit of nested function calls
+const maxCallDepth = 10000
+
+// CallFrame is struct of frame of function call
+type CallFrame struct {
+	Name string
+	File string
+	Line int // line currently executed in the frame
+}
+
+// NewCallFrame is constructor of CallFrame
+func NewCallFrame(name string, file string, line int) *CallFrame {
+	return &CallFrame{
+		Name: name,
+		File: file,
+		Line: line,
+	}
+}
+
+func (f CallFrame) String() string {
+	return fmt.Sprintf("  File \"%v\", line %v, in %v", f.File, f.Line, f.Name)
+}
+
+// Traceback is snapshot of call frames from the outermost one
+type Traceback []CallFrame
+
+func (t Traceback) String() string {
+	// frames of builtin and native functions have no source line to show
+	frames := make(Traceback, 0, len(t))
+	for _, f := range t {
+		if f.Line > 0 || !strings.HasPrefix(f.File, "<") {
+			frames = append(frames, f)
+		}
+	}
+	t = frames
+
+	lines := []string{"Traceback (most recent call last):"}
+	for k := 0; k < len(t); {
+		// collapse repeated frames such as deep recursion
+		n := 1
+		for k+n < len(t) && t[k+n] == t[k] {
+			n++
+		}
+		if n > 3 {
+			lines = append(lines, t[k].String(), t[k].String(), t[k].String())
+			lines = append(lines, fmt.Sprintf("  [Previous line repeated %d more times]", n-3))
+		} else {
+			for j := 0; j < n; j++ {
+				lines = append(lines, t[k].String())
+			}
+		}
+		k += n
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// CallStack is struct of stack for call frames
+type CallStack struct {
+	Stack *stack.Stack
+}
+
+// NewCallStack is constructor of CallStack
+func NewCallStack() *CallStack {
+	return &CallStack{stack.NewStack()}
+}
+
+// Push adds an item in stack
+func (s *CallStack) Push(x *CallFrame) {
+	s.Stack.Push(x)
+}
+
+// Pop pops an item from stack
+func (s *CallStack) Pop() *CallFrame {
+	return s.Stack.Pop().(*CallFrame)
+}
+
+// Peek returns top item in stack, and don't modity the stack.
+func (s *CallStack) Peek() *CallFrame {
+	return s.Stack.Peek().(*CallFrame)
+}
+
+// IsEmpty checks that stack is empty
+func (s *CallStack) IsEmpty() bool {
+	return s.Stack.IsEmpty()
+}
+
+// Size returns stack size
+func (s *CallStack) Size() int {
+	return s.Stack.Size()
+}
+
+// SetLine records the line currently executed in the innermost frame
+func (s *CallStack) SetLine(line int) {
+	if s.IsEmpty() {
+		return
+	}
+	s.Peek().Line = line
+}
+
+// Traceback returns snapshot of frames.
+// line is used as the line of the innermost frame.
+func (s *CallStack) Traceback(line int) Traceback {
+	if s.IsEmpty() {
+		return nil
+	}
+
+	trace := make(Traceback, 0, s.Size())
+	for _, v := range s.Stack.Data[:s.Size()] {
+		trace = append(trace, *v.(*CallFrame))
+	}
+	trace[len(trace)-1].Line = line
+
+	return trace
+}
+
+// attachTraceback records current call stack to err if it doesn't have one yet.
+func (i *Interpreter) attachTraceback(err error) {
+	switch e := err.(type) {
+	case *CustomError:
+		if e.Trace == nil && e.Token != nil {
+			e.Trace = i.Runtime.CallStack.Traceback(e.Token.Line)
+		}
+	case *ExceptionValue:
+		if e.Trace == nil && e.Token != nil {
+			e.Trace = i.Runtime.CallStack.Traceback(e.Token.Line)
+		}
+	}
+}
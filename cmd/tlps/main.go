@@ -1,8 +1,8 @@
 package main
 
 import (
-	"bufio"
 	"bytes"
+	"flag"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -15,14 +15,42 @@ import (
 	"github.com/goropikari/tlps"
 )
 
+// searchPath is list of directories given by -I flags
+type searchPath []string
+
+func (s *searchPath) String() string {
+	return strings.Join(*s, string(filepath.ListSeparator))
+}
+
+func (s *searchPath) Set(dir string) error {
+	*s = append(*s, dir)
+	return nil
+}
+
 func main() {
 	runtime := tlps.NewRuntime()
 
-	if len(os.Args) > 2 {
-		fmt.Println("Usage: tlps [script]")
+	var includes searchPath
+	flag.Var(&includes, "I", "add directory to search path of include and import (can be repeated)")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "Usage: tlps [-I dir]... [script]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	// -I directories take precedence over TLPSPATH
+	runtime.SearchPath = includes
+	for _, dir := range filepath.SplitList(os.Getenv("TLPSPATH")) {
+		if dir != "" {
+			runtime.SearchPath = append(runtime.SearchPath, dir)
+		}
+	}
+
+	if flag.NArg() > 1 {
+		flag.Usage()
 		os.Exit(64)
-	} else if len(os.Args) == 2 {
-		runFile(os.Args[1], runtime)
+	} else if flag.NArg() == 1 {
+		runFile(flag.Arg(0), runtime)
 	} else {
 		runPrompt(runtime)
 	}
@@ -34,6 +62,7 @@ func runFile(path string, r *tlps.Runtime) {
 		log.Fatal(err)
 	}
 	r.BasePath = filepath.Dir(path)
+	r.File = path
 
 	// fmt.Println(source)
 	r.Run(bytes.NewBuffer(source))
@@ -47,7 +76,8 @@ func runFile(path string, r *tlps.Runtime) {
 }
 
 func runPrompt(r *tlps.Runtime) {
-	stdin := bufio.NewReader(os.Stdin)
+	// share the reader with scripts so that input() and stdin don't lose buffered input
+	stdin := r.Stdin
 	buf := &bytes.Buffer{}
 
 	for {
@@ -61,14 +91,14 @@ func runPrompt(r *tlps.Runtime) {
 				log.Fatal(err)
 			}
 
-			if len(line) == 0 {
+			if len(line) == 0 && !r.InTripleQuote(buf.String()) {
 				break
 			}
 
 			buf.Write(line)
 			buf.Write([]byte{'\n'})
 
-			if !canContinueRead(string(line)) {
+			if !r.InTripleQuote(buf.String()) && !canContinueRead(string(line)) {
 				break
 			}
 
@@ -88,7 +118,12 @@ func canContinueRead(line string) bool {
 		return true
 	}
 
-	blocks := []string{"class", "def", "else", "elseif", "for", "fun", "if", "while"}
+	// e.g., lambda with indented body
+	if strings.HasSuffix(strings.TrimSpace(line), ":") {
+		return true
+	}
+
+	blocks := []string{"class", "def", "else", "elseif", "except", "finally", "for", "fun", "if", "try", "while"}
 	for _, v := range blocks {
 		if strings.HasPrefix(line, v) {
 			return true
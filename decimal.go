@@ -0,0 +1,298 @@
+package tlps
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// decimalPrecision is the number of significant digits of inexact division of decimal
+const decimalPrecision = 28
+
+// maxPowDigits is the limit of the number of digits made by exponent,
+// i.e., power of numbers and exponent notation of decimal
+const maxPowDigits = 1000000
+
+var bigTen = big.NewInt(10)
+
+// Decimal is exact base-10 number.
+// The value is unscaled * 10^-scale, and scale is never negative.
+// Trailing zeros are kept, so that decimal("1.10") is printed as 1.10.
+type Decimal struct {
+	unscaled *big.Int
+	scale    int
+}
+
+// NewDecimal is constructor of Decimal
+func NewDecimal(unscaled *big.Int, scale int) *Decimal {
+	if scale < 0 {
+		unscaled = new(big.Int).Mul(unscaled, pow10(-scale))
+		scale = 0
+	}
+	return &Decimal{unscaled: unscaled, scale: scale}
+}
+
+// ParseDecimal parses string such as "-12.345" or "1.5e3" to Decimal
+func ParseDecimal(s string) (*Decimal, error) {
+	s = strings.TrimSpace(s)
+	mantissa, exponent := s, int64(0)
+	if k := strings.IndexAny(s, "eE"); k >= 0 {
+		mantissa = s[:k]
+		e, ok := new(big.Int).SetString(s[k+1:], 10)
+		if !ok || !e.IsInt64() || e.Int64() > math.MaxInt32 || e.Int64() < math.MinInt32 {
+			return nil, errors.New("Invalid decimal literal.")
+		}
+		exponent = e.Int64()
+	}
+
+	sign := ""
+	if strings.HasPrefix(mantissa, "-") || strings.HasPrefix(mantissa, "+") {
+		sign, mantissa = mantissa[:1], mantissa[1:]
+	}
+	integer, fraction := mantissa, ""
+	if k := strings.IndexByte(mantissa, '.'); k >= 0 {
+		integer, fraction = mantissa[:k], mantissa[k+1:]
+	}
+	digits := integer + fraction
+	if digits == "" || strings.Trim(digits, "0123456789") != "" {
+		return nil, errors.New("Invalid decimal literal.")
+	}
+
+	// scale is expanded into digits, e.g., 1e9 has 10 digits
+	scale := int64(len(fraction)) - exponent
+	if scale > maxPowDigits || scale < -maxPowDigits {
+		return nil, errors.New("Exponent is too large.")
+	}
+
+	unscaled, _ := new(big.Int).SetString(sign+digits, 10)
+	return NewDecimal(unscaled, int(scale)), nil
+}
+
+// toDecimal converts integer or decimal to Decimal
+func toDecimal(v interface{}) *Decimal {
+	switch n := v.(type) {
+	case int64:
+		return NewDecimal(big.NewInt(n), 0)
+	case *big.Int:
+		return NewDecimal(n, 0)
+	case *Decimal:
+		return n
+	}
+	return nil
+}
+
+func isDecimal(v interface{}) bool {
+	_, ok := v.(*Decimal)
+	return ok
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
+
+// rescale returns unscaled value of d in given scale which must not be less than d.scale
+func (d *Decimal) rescale(scale int) *big.Int {
+	if scale == d.scale {
+		return d.unscaled
+	}
+	return new(big.Int).Mul(d.unscaled, pow10(scale-d.scale))
+}
+
+// Rat returns value of d as rational number
+func (d *Decimal) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(d.unscaled, pow10(d.scale))
+}
+
+// Sign returns -1, 0 or 1 according to the sign of d
+func (d *Decimal) Sign() int {
+	return d.unscaled.Sign()
+}
+
+// IsInteger checks that d has no fractional part
+func (d *Decimal) IsInteger() bool {
+	return d.Rat().IsInt()
+}
+
+// Cmp compares d and e
+func (d *Decimal) Cmp(e *Decimal) int {
+	scale := maxInt(d.scale, e.scale)
+	return d.rescale(scale).Cmp(e.rescale(scale))
+}
+
+// Neg returns -d
+func (d *Decimal) Neg() *Decimal {
+	return NewDecimal(new(big.Int).Neg(d.unscaled), d.scale)
+}
+
+// Add returns d + e
+func (d *Decimal) Add(e *Decimal) *Decimal {
+	scale := maxInt(d.scale, e.scale)
+	return NewDecimal(new(big.Int).Add(d.rescale(scale), e.rescale(scale)), scale)
+}
+
+// Sub returns d - e
+func (d *Decimal) Sub(e *Decimal) *Decimal {
+	scale := maxInt(d.scale, e.scale)
+	return NewDecimal(new(big.Int).Sub(d.rescale(scale), e.rescale(scale)), scale)
+}
+
+// Mul returns d * e
+func (d *Decimal) Mul(e *Decimal) *Decimal {
+	return NewDecimal(new(big.Int).Mul(d.unscaled, e.unscaled), d.scale+e.scale)
+}
+
+// Quo returns d / e. e must not be zero.
+// The result is exact if it can be written in finite digits,
+// otherwise it is rounded half to even to decimalPrecision significant digits.
+func (d *Decimal) Quo(e *Decimal) *Decimal {
+	q := new(big.Rat).Quo(d.Rat(), e.Rat())
+	scale := maxInt(d.scale-e.scale, 0)
+	for {
+		n := new(big.Rat).Mul(q, new(big.Rat).SetInt(pow10(scale)))
+		if n.IsInt() {
+			return NewDecimal(new(big.Int).Set(n.Num()), scale)
+		}
+
+		quo, rem := new(big.Int).QuoRem(n.Num(), n.Denom(), new(big.Int))
+		if quo.Sign() != 0 && len(new(big.Int).Abs(quo).String()) >= decimalPrecision {
+			return NewDecimal(roundHalfEven(quo, rem, n.Denom()), scale)
+		}
+		scale++
+	}
+}
+
+// roundHalfEven rounds truncated quotient quo by remainder rem and divisor denom
+func roundHalfEven(quo *big.Int, rem *big.Int, denom *big.Int) *big.Int {
+	c := new(big.Int).Mul(new(big.Int).Abs(rem), big.NewInt(2)).Cmp(denom)
+	if c > 0 || c == 0 && quo.Bit(0) == 1 {
+		if rem.Sign() < 0 {
+			return quo.Sub(quo, big.NewInt(1))
+		}
+		return quo.Add(quo, big.NewInt(1))
+	}
+	return quo
+}
+
+// FloorDiv returns the largest integer which is not greater than d / e. e must not be zero.
+func (d *Decimal) FloorDiv(e *Decimal) *Decimal {
+	q := new(big.Rat).Quo(d.Rat(), e.Rat())
+	quo, rem := new(big.Int).QuoRem(q.Num(), q.Denom(), new(big.Int))
+	if rem.Sign() < 0 {
+		quo.Sub(quo, big.NewInt(1))
+	}
+	return NewDecimal(quo, 0)
+}
+
+// Mod returns d - e * (d // e). The result has the same sign as e.
+func (d *Decimal) Mod(e *Decimal) *Decimal {
+	return d.Sub(e.Mul(d.FloorDiv(e)))
+}
+
+// Pow returns d ** n
+func (d *Decimal) Pow(n *big.Int) *Decimal {
+	if n.Sign() < 0 {
+		return NewDecimal(big.NewInt(1), 0).Quo(d.Pow(new(big.Int).Neg(n)))
+	}
+	unscaled := new(big.Int).Exp(d.unscaled, n, nil)
+	return NewDecimal(unscaled, d.scale*int(n.Int64()))
+}
+
+func (d *Decimal) String() string {
+	digits := new(big.Int).Abs(d.unscaled).String()
+	if d.scale > 0 {
+		if len(digits) <= d.scale {
+			digits = strings.Repeat("0", d.scale-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-d.scale] + "." + digits[len(digits)-d.scale:]
+	}
+	if d.Sign() < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+// decimalArithmetic evaluates binary arithmetic operation which has decimal operand.
+// Decimal can't be mixed with float since the result would be inexact.
+func decimalArithmetic(operator *Token, left interface{}, right interface{}) (interface{}, error) {
+	if !isDecimal(left) && !isInteger(left) || !isDecimal(right) && !isInteger(right) {
+		return nil, RuntimeError.New(operator, "Can't mix decimal and float.")
+	}
+	l, r := toDecimal(left), toDecimal(right)
+
+	switch operator.Type {
+	case PlusTT:
+		return l.Add(r), nil
+	case MinusTT:
+		return l.Sub(r), nil
+	case StarTT:
+		return l.Mul(r), nil
+	case SlashTT:
+		return l.Quo(r), nil
+	case SlashSlashTT:
+		return l.FloorDiv(r), nil
+	case PercentTT:
+		return l.Mod(r), nil
+	case StarStarTT:
+		if !r.IsInteger() {
+			return nil, RuntimeError.New(operator, "Exponent of decimal must be an integer.")
+		}
+		n := new(big.Int).Quo(r.unscaled, pow10(r.scale))
+		if !n.IsInt64() || n.Int64() > math.MaxInt32 || n.Int64() < math.MinInt32 {
+			return nil, RuntimeError.New(operator, "Exponent is too large.")
+		}
+		if powDigits(l, n.Int64()) > maxPowDigits {
+			return nil, RuntimeError.New(operator, "Exponent is too large.")
+		}
+		if n.Sign() < 0 && l.Sign() == 0 {
+			return nil, RuntimeError.New(operator, "Division by zero.")
+		}
+		return l.Pow(n), nil
+	}
+
+	// Unreachable
+	return nil, RuntimeError.New(operator, "Unreachable")
+}
+
+// powDigits estimates the number of digits of unscaled value and scale of d ** n
+func powDigits(d *Decimal, n int64) float64 {
+	if n < 0 {
+		n = -n
+	}
+	digits := float64(d.unscaled.BitLen()-1) * math.Log10(2)
+	return math.Max(digits, float64(d.scale)) * float64(n)
+}
+
+// DecimalFunc is native function to make decimal from string or integer
+// ex. decimal("0.1")
+type DecimalFunc struct{}
+
+// NewDecimalFunc is constructor of DecimalFunc
+func NewDecimalFunc() *DecimalFunc {
+	return &DecimalFunc{}
+}
+
+// Arity returns arity of decimal function
+func (df *DecimalFunc) Arity() (int, int) {
+	return 1, 1
+}
+
+// Call converts argument to decimal
+func (df *DecimalFunc) Call(arguments []interface{}) (interface{}, error) {
+	switch v := arguments[0].(type) {
+	case string:
+		return ParseDecimal(v)
+	case int64, *big.Int, *Decimal:
+		return toDecimal(v), nil
+	case float64:
+		return nil, errors.New("Can't convert float to decimal exactly. Use string instead.")
+	}
+	return nil, errors.New("Argument must be a string or an integer.")
+}
+
+func maxInt(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
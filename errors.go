@@ -1,5 +1,7 @@
 package tlps
 
+import "strings"
+
 var (
 	ParseError   = NewCustomError("ParseError")
 	RuntimeError = NewCustomError("RuntimeError")
@@ -9,6 +11,7 @@ type CustomError struct {
 	typ     string
 	Token   *Token
 	message string
+	Trace   Traceback
 }
 
 func (e *CustomError) Error() string {
@@ -22,3 +25,35 @@ func (e *CustomError) New(token *Token, message string) error {
 func NewCustomError(typ string) *CustomError {
 	return &CustomError{typ: typ}
 }
+
+// ParseErrors is list of syntax errors found by parser
+type ParseErrors []error
+
+// Error satisfies error interface
+func (e ParseErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "\n")
+}
+
+// ExceptionValue is error to propagate an exception raised by raise statement
+type ExceptionValue struct {
+	Value *TLPSInstance
+	Token *Token
+	Trace Traceback
+}
+
+// Error satisfies error interface
+func (e *ExceptionValue) Error() string {
+	if message, ok := e.Value.Fields["message"]; ok && message != "" {
+		return e.Value.Klass.Name + ": " + stringfy(message)
+	}
+	return e.Value.Klass.Name
+}
+
+// NewExceptionValue is constructor of ExceptionValue
+func NewExceptionValue(value *TLPSInstance, token *Token) *ExceptionValue {
+	return &ExceptionValue{Value: value, Token: token}
+}
@@ -9,11 +9,22 @@ type VisitorExpr interface {
 	visitAssignExpr(*Assign) (interface{}, error)
 	visitBinaryExpr(*Binary) (interface{}, error)
 	visitCallExpr(*Call) (interface{}, error)
+	visitComparisonExpr(*Comparison) (interface{}, error)
+	visitConditionalExpr(*Conditional) (interface{}, error)
 	visitGetExpr(*Get) (interface{}, error)
 	visitGroupingExpr(*Grouping) (interface{}, error)
+	visitIndexExpr(*Index) (interface{}, error)
+	visitInterpolationExpr(*Interpolation) (interface{}, error)
+	visitKeywordArgExpr(*KeywordArg) (interface{}, error)
+	visitLambdaExpr(*Lambda) (interface{}, error)
+	visitListExpr(*List) (interface{}, error)
 	visitLiteralExpr(*Literal) (interface{}, error)
 	visitLogicalExpr(*Logical) (interface{}, error)
+	visitMapExpr(*Map) (interface{}, error)
 	visitSetExpr(*Set) (interface{}, error)
+	visitSetIndexExpr(*SetIndex) (interface{}, error)
+	visitSliceExpr(*Slice) (interface{}, error)
+	visitSpreadExpr(*Spread) (interface{}, error)
 	visitSuperExpr(*Super) (interface{}, error)
 	visitThisExpr(*This) (interface{}, error)
 	visitUnaryExpr(*Unary) (interface{}, error)
@@ -85,6 +96,49 @@ func (rec *Call) IsType(v interface{}) bool {
 	return false
 }
 
+type Comparison struct {
+	Operands  []Expr
+	Operators []*Token
+}
+
+func NewComparison(operands []Expr, operators []*Token) Expr {
+	return &Comparison{operands, operators}
+}
+
+func (c *Comparison) Accept(visitor VisitorExpr) (interface{}, error) {
+	return visitor.visitComparisonExpr(c)
+}
+
+func (rec *Comparison) IsType(v interface{}) bool {
+	switch v.(type) {
+	case *Comparison:
+		return true
+	}
+	return false
+}
+
+type Conditional struct {
+	Condition  Expr
+	ThenBranch Expr
+	ElseBranch Expr
+}
+
+func NewConditional(condition Expr, thenBranch Expr, elseBranch Expr) Expr {
+	return &Conditional{condition, thenBranch, elseBranch}
+}
+
+func (c *Conditional) Accept(visitor VisitorExpr) (interface{}, error) {
+	return visitor.visitConditionalExpr(c)
+}
+
+func (rec *Conditional) IsType(v interface{}) bool {
+	switch v.(type) {
+	case *Conditional:
+		return true
+	}
+	return false
+}
+
 type Get struct {
 	Object Expr
 	Name   *Token
@@ -126,6 +180,111 @@ func (rec *Grouping) IsType(v interface{}) bool {
 	return false
 }
 
+type Index struct {
+	Object  Expr
+	Bracket *Token
+	Index   Expr
+}
+
+func NewIndex(object Expr, bracket *Token, index Expr) Expr {
+	return &Index{object, bracket, index}
+}
+
+func (i *Index) Accept(visitor VisitorExpr) (interface{}, error) {
+	return visitor.visitIndexExpr(i)
+}
+
+func (rec *Index) IsType(v interface{}) bool {
+	switch v.(type) {
+	case *Index:
+		return true
+	}
+	return false
+}
+
+type Interpolation struct {
+	Fstring *Token
+	Parts   []Expr
+}
+
+func NewInterpolation(fstring *Token, parts []Expr) Expr {
+	return &Interpolation{fstring, parts}
+}
+
+func (i *Interpolation) Accept(visitor VisitorExpr) (interface{}, error) {
+	return visitor.visitInterpolationExpr(i)
+}
+
+func (rec *Interpolation) IsType(v interface{}) bool {
+	switch v.(type) {
+	case *Interpolation:
+		return true
+	}
+	return false
+}
+
+type KeywordArg struct {
+	Name  *Token
+	Value Expr
+}
+
+func NewKeywordArg(name *Token, value Expr) Expr {
+	return &KeywordArg{name, value}
+}
+
+func (k *KeywordArg) Accept(visitor VisitorExpr) (interface{}, error) {
+	return visitor.visitKeywordArgExpr(k)
+}
+
+func (rec *KeywordArg) IsType(v interface{}) bool {
+	switch v.(type) {
+	case *KeywordArg:
+		return true
+	}
+	return false
+}
+
+type Lambda struct {
+	Function *Function
+}
+
+func NewLambda(function *Function) Expr {
+	return &Lambda{function}
+}
+
+func (l *Lambda) Accept(visitor VisitorExpr) (interface{}, error) {
+	return visitor.visitLambdaExpr(l)
+}
+
+func (rec *Lambda) IsType(v interface{}) bool {
+	switch v.(type) {
+	case *Lambda:
+		return true
+	}
+	return false
+}
+
+type List struct {
+	Bracket  *Token
+	Elements []Expr
+}
+
+func NewList(bracket *Token, elements []Expr) Expr {
+	return &List{bracket, elements}
+}
+
+func (l *List) Accept(visitor VisitorExpr) (interface{}, error) {
+	return visitor.visitListExpr(l)
+}
+
+func (rec *List) IsType(v interface{}) bool {
+	switch v.(type) {
+	case *List:
+		return true
+	}
+	return false
+}
+
 type Literal struct {
 	Value interface{}
 }
@@ -168,6 +327,28 @@ func (rec *Logical) IsType(v interface{}) bool {
 	return false
 }
 
+type Map struct {
+	Brace  *Token
+	Keys   []Expr
+	Values []Expr
+}
+
+func NewMap(brace *Token, keys []Expr, values []Expr) Expr {
+	return &Map{brace, keys, values}
+}
+
+func (m *Map) Accept(visitor VisitorExpr) (interface{}, error) {
+	return visitor.visitMapExpr(m)
+}
+
+func (rec *Map) IsType(v interface{}) bool {
+	switch v.(type) {
+	case *Map:
+		return true
+	}
+	return false
+}
+
 type Set struct {
 	Object Expr
 	Name   *Token
@@ -190,6 +371,73 @@ func (rec *Set) IsType(v interface{}) bool {
 	return false
 }
 
+type SetIndex struct {
+	Object  Expr
+	Bracket *Token
+	Index   Expr
+	Value   Expr
+}
+
+func NewSetIndex(object Expr, bracket *Token, index Expr, value Expr) Expr {
+	return &SetIndex{object, bracket, index, value}
+}
+
+func (s *SetIndex) Accept(visitor VisitorExpr) (interface{}, error) {
+	return visitor.visitSetIndexExpr(s)
+}
+
+func (rec *SetIndex) IsType(v interface{}) bool {
+	switch v.(type) {
+	case *SetIndex:
+		return true
+	}
+	return false
+}
+
+type Slice struct {
+	Object  Expr
+	Bracket *Token
+	Start   Expr
+	Stop    Expr
+}
+
+func NewSlice(object Expr, bracket *Token, start Expr, stop Expr) Expr {
+	return &Slice{object, bracket, start, stop}
+}
+
+func (s *Slice) Accept(visitor VisitorExpr) (interface{}, error) {
+	return visitor.visitSliceExpr(s)
+}
+
+func (rec *Slice) IsType(v interface{}) bool {
+	switch v.(type) {
+	case *Slice:
+		return true
+	}
+	return false
+}
+
+type Spread struct {
+	Star  *Token
+	Value Expr
+}
+
+func NewSpread(star *Token, value Expr) Expr {
+	return &Spread{star, value}
+}
+
+func (s *Spread) Accept(visitor VisitorExpr) (interface{}, error) {
+	return visitor.visitSpreadExpr(s)
+}
+
+func (rec *Spread) IsType(v interface{}) bool {
+	switch v.(type) {
+	case *Spread:
+		return true
+	}
+	return false
+}
+
 type Super struct {
 	Keyword *Token
 	Method  *Token
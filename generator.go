@@ -0,0 +1,169 @@
+package tlps
+
+import "errors"
+
+// Generator is iterator returned by calling function which has yield.
+// The function body runs in its own goroutine, and it's suspended at each yield
+// until next value is requested. Only one of the caller and the generator runs at a time,
+// so they share the interpreter.
+// for-in loop closes the generator when it exits before the generator is exhausted,
+// and script can close it by close method.
+// Note that goroutine of generator which is neither exhausted nor closed remains until the program exits.
+type Generator struct {
+	name        string
+	file        string
+	declaration *Function
+	environment *Environment
+	coroutine   *coroutine
+	interpreter *Interpreter // interpreter which runs the body
+	started     bool
+	running     bool
+	done        bool
+}
+
+// coroutine is channels to switch execution between caller and generator
+type coroutine struct {
+	resume  chan struct{}
+	yield   chan generatorResult
+	closing bool // yield raises GeneratorExit when it's true
+}
+
+type generatorResult struct {
+	value interface{}
+	done  bool
+	err   error
+}
+
+// NewGenerator is constructor of Generator.
+// environment is the one where arguments are bound.
+func NewGenerator(name string, file string, declaration *Function, environment *Environment) *Generator {
+	return &Generator{
+		name:        name,
+		file:        file,
+		declaration: declaration,
+		environment: environment,
+		coroutine: &coroutine{
+			resume: make(chan struct{}),
+			yield:  make(chan generatorResult),
+		},
+	}
+}
+
+// Next resumes the generator until next yield
+func (g *Generator) Next(i *Interpreter) (interface{}, bool, error) {
+	if g.done {
+		return nil, false, nil
+	}
+	if g.running {
+		return nil, false, errors.New("Generator is already running.")
+	}
+
+	result := g.resume(i)
+	if result.done {
+		g.done = true
+		return nil, false, result.err
+	}
+	return result.value, true, nil
+}
+
+// Close finishes the generator which isn't exhausted.
+// The suspended yield raises GeneratorExit so that finally clauses of the body run.
+func (g *Generator) Close(i *Interpreter) error {
+	if g.done {
+		return nil
+	}
+	if g.running {
+		return errors.New("Generator is already running.")
+	}
+	if !g.started {
+		g.done = true
+		return nil
+	}
+
+	g.coroutine.closing = true
+	result := g.resume(i)
+	g.done = true
+	return result.err
+}
+
+// resume switches execution to the generator until it yields or finishes.
+// The body keeps running on the interpreter which started it
+// because yield suspends the coroutine of that interpreter.
+func (g *Generator) resume(i *Interpreter) generatorResult {
+	if !g.started {
+		g.interpreter = i
+	}
+	i = g.interpreter
+
+	callStack := i.Runtime.CallStack
+	callStack.Push(NewCallFrame(g.name, g.file, g.declaration.Name.Line))
+	defer callStack.Pop()
+
+	environment := i.Runtime.Environment
+	enclosing := i.coroutine
+	i.coroutine = g.coroutine
+	g.running = true
+
+	if !g.started {
+		g.started = true
+		go g.run(i)
+	} else {
+		g.coroutine.resume <- struct{}{}
+	}
+	result := <-g.coroutine.yield
+
+	g.running = false
+	i.coroutine = enclosing
+	i.Runtime.Environment = environment
+
+	return result
+}
+
+// run executes function body in goroutine
+func (g *Generator) run(i *Interpreter) {
+	co := g.coroutine
+	_, err := i.executeBlock(g.declaration.Body, g.environment)
+	switch err.(type) {
+	case *ReturnValue:
+		// returned value is discarded
+		err = nil
+	case *GeneratorExit:
+		err = nil
+	}
+	if err != nil {
+		i.attachTraceback(err)
+	}
+	co.yield <- generatorResult{done: true, err: err}
+}
+
+// Get returns builtin method of generator
+func (g *Generator) Get(name *Token) (interface{}, error) {
+	switch name.Lexeme {
+	case "close":
+		return NewBuiltinMethod(name.Lexeme, 0, g.close), nil
+	}
+
+	return nil, RuntimeError.New(name, "Undefined property '"+name.Lexeme+"'.")
+}
+
+// close finishes the generator. Generator which isn't started has no interpreter yet.
+func (g *Generator) close(arguments []interface{}) (interface{}, error) {
+	return nil, g.Close(g.interpreter)
+}
+
+func (g *Generator) String() string {
+	return "<generator " + g.name + ">"
+}
+
+// GeneratorExit is pseudo error to unwind body of generator being closed
+type GeneratorExit struct{}
+
+// Error satisfies error interface
+func (e *GeneratorExit) Error() string {
+	return "Generator exit"
+}
+
+// NewGeneratorExit is constructor of GeneratorExit
+func NewGeneratorExit() *GeneratorExit {
+	return &GeneratorExit{}
+}
@@ -3,29 +3,52 @@ package tlps
 import (
 	"bytes"
 	"fmt"
-	"os"
+	"math/big"
 	"path/filepath"
 	"reflect"
+	"strconv"
+	"strings"
 
 	"github.com/goropikari/tlps/native_function"
 )
 
 // Interpreter is struct of interpreter
 type Interpreter struct {
-	Runtime *Runtime
+	Runtime   *Runtime
+	coroutine *coroutine // coroutine of generator being executed
 }
 
 // NewInterpreter is constructor of Interpreter
 func NewInterpreter(runtime *Runtime) *Interpreter {
-	globals := runtime.Globals
-
-	globals.Define("clock", NewNativeFunction(native_function.NewClockFunc()))
-	globals.Define("exit", NewNativeFunction(native_function.NewExitFunc()))
-	globals.Define("print", NewNativeFunction(native_function.NewPrintFunc()))
-
-	return &Interpreter{
+	globals := runtime.Builtins
+
+	globals.Define("clock", NewNativeFunction("clock", native_function.NewClockFunc()))
+	globals.Define("decimal", NewNativeFunction("decimal", NewDecimalFunc()))
+	globals.Define("iter", NewBuiltinFunction("iter", 1, 1, builtinIter))
+	globals.Define("next", NewBuiltinFunction("next", 1, 1, builtinNext))
+	globals.Define("range", NewNativeFunction("range", NewRangeFunc()))
+	globals.Define("exit", NewNativeFunction("exit", native_function.NewExitFunc()))
+	globals.Define("print", NewNativeFunction("print", native_function.NewPrintFunc(stringfy)))
+
+	// file I/O
+	globals.Define("open", NewBuiltinFunction("open", 1, 2, builtinOpen))
+	globals.Define("read_file", NewBuiltinFunction("read_file", 1, 1, builtinReadFile))
+	globals.Define("write_file", NewBuiltinFunction("write_file", 2, 2, builtinWriteFile))
+	globals.Define("exists", NewBuiltinFunction("exists", 1, 1, builtinExists))
+	globals.Define("listdir", NewBuiltinFunction("listdir", 0, 1, builtinListdir))
+	globals.Define("mkdir", NewBuiltinFunction("mkdir", 1, 1, builtinMkdir))
+	globals.Define("remove", NewBuiltinFunction("remove", 1, 1, builtinRemove))
+	globals.Define("input", NewBuiltinFunction("input", 0, 1, builtinInput))
+	globals.Define("stdin", newStdin(runtime.Stdin))
+
+	interpreter := &Interpreter{
 		Runtime: runtime,
 	}
+	if runtime.ExceptionClass == nil {
+		interpreter.loadPrelude()
+	}
+
+	return interpreter
 }
 
 // Interpret interprets given statements
@@ -55,71 +78,33 @@ func (i *Interpreter) visitBinaryExpr(expr *Binary) (interface{}, error) {
 	}
 
 	switch expr.Operator.Type {
-	case GreaterTT:
-		err := checkNumberOperands(expr.Operator, left, right)
-		if err != nil {
-			return nil, err
-		}
-		return left.(float64) > right.(float64), nil
-	case GreaterEqualTT:
-		err := checkNumberOperands(expr.Operator, left, right)
+	case GreaterTT, GreaterEqualTT, LessTT, LessEqualTT:
+		result, err := compare(expr.Operator, left, right)
 		if err != nil {
 			return nil, err
 		}
-		return left.(float64) >= right.(float64), nil
-	case LessTT:
-		err := checkNumberOperands(expr.Operator, left, right)
-		if err != nil {
-			return nil, err
-		}
-		return left.(float64) < right.(float64), nil
-	case LessEqualTT:
-		err := checkNumberOperands(expr.Operator, left, right)
-		if err != nil {
-			return nil, err
-		}
-		return left.(float64) <= right.(float64), nil
+		return result, nil
 	case BangEqualTT:
-		// err := checkNumberOperands(expr.Operator, left, right)
-		// if err != nil {
-		// 	return nil, err
-		// }
-		// return left.(float64) != right.(float64), nil
-		return left != right, nil
+		return !isEqual(left, right), nil
 	case EqualEqualTT:
-		// err := checkNumberOperands(expr.Operator, left, right)
-		// if err != nil {
-		// 	return nil, err
-		// }
-		// return left.(float64) == right.(float64), nil
-		return left == right, nil
-	case MinusTT:
-		err := checkNumberOperands(expr.Operator, left, right)
-		if err != nil {
-			return nil, err
-		}
-		return left.(float64) - right.(float64), nil
+		return isEqual(left, right), nil
 	case PlusTT:
-		if isFloat64(left) && isFloat64(right) {
-			return left.(float64) + right.(float64), nil
+		if isNumber(left) && isNumber(right) {
+			return arithmetic(expr.Operator, left, right)
 		}
 		if isString(left) && isString(right) {
 			return left.(string) + right.(string), nil
 		}
 
 		return nil, RuntimeError.New(expr.Operator, "Operands must be two numbers or two strings.")
-	case SlashTT:
+	case MinusTT, SlashTT, StarTT, SlashSlashTT, PercentTT, StarStarTT:
 		err := checkNumberOperands(expr.Operator, left, right)
 		if err != nil {
 			return nil, err
 		}
-		return left.(float64) / right.(float64), nil
-	case StarTT:
-		err := checkNumberOperands(expr.Operator, left, right)
-		if err != nil {
-			return nil, err
-		}
-		return left.(float64) * right.(float64), nil
+		return arithmetic(expr.Operator, left, right)
+	case AmpersandTT, PipeTT, CaretTT, LessLessTT, GreaterGreaterTT:
+		return bitwise(expr.Operator, left, right)
 	}
 
 	// Unreachable.
@@ -133,7 +118,31 @@ func (i *Interpreter) visitCallExpr(expr *Call) (interface{}, error) {
 	}
 
 	arguments := make([]interface{}, 0)
+	keywords := make([]*KeywordArg, 0)
+	keywordValues := make([]interface{}, 0)
 	for _, argument := range expr.Arguments {
+		if keyword, ok := argument.(*KeywordArg); ok {
+			value, err := i.evaluate(keyword.Value)
+			if err != nil {
+				return nil, err
+			}
+			keywords = append(keywords, keyword)
+			keywordValues = append(keywordValues, value)
+			continue
+		}
+		if spread, ok := argument.(*Spread); ok {
+			value, err := i.evaluate(spread.Value)
+			if err != nil {
+				return nil, err
+			}
+			list, ok := value.(*TLPSList)
+			if !ok {
+				return nil, RuntimeError.New(spread.Star, "Can only spread list, not "+typeName(value)+".")
+			}
+			arguments = append(arguments, list.Elements...)
+			continue
+		}
+
 		arg, err := i.evaluate(argument)
 		if err != nil {
 			return nil, err
@@ -146,13 +155,69 @@ func (i *Interpreter) visitCallExpr(expr *Call) (interface{}, error) {
 		return nil, RuntimeError.New(expr.Paren, "Can only call functions and classes.")
 	}
 
-	if function.Arity() == -1 {
-		return function.Call(i, arguments)
-	} else if len(arguments) != function.Arity() {
-		return nil, RuntimeError.New(expr.Paren, fmt.Sprintf("Expected %d arguments but got %d.", function.Arity(), len(arguments)))
+	if len(keywords) != 0 {
+		arguments, err = bindKeywords(function, arguments, keywords, keywordValues)
+		if err != nil {
+			return nil, RuntimeError.New(expr.Paren, err.Error())
+		}
+	}
+	if message, ok := checkArity(function, len(arguments)); !ok {
+		return nil, RuntimeError.New(expr.Paren, message)
+	}
+	if i.Runtime.CallStack.Size() >= maxCallDepth {
+		return nil, RuntimeError.New(expr.Paren, "Maximum recursion depth exceeded.")
+	}
+
+	i.Runtime.CallStack.SetLine(expr.Paren.Line)
+	value, err := function.Call(i, arguments)
+	if err != nil {
+		switch function.(type) {
+		case *NativeFunction, *BuiltinMethod, *BuiltinFunction:
+			// native functions don't know where they are called
+			return nil, locateError(expr.Paren, err)
+		}
+		return nil, err
+	}
+
+	return value, nil
+}
+
+// visitComparisonExpr evaluates chained comparison such as a < b <= c.
+// Each operand is evaluated at most once, and the evaluation stops at the first false comparison.
+func (i *Interpreter) visitComparisonExpr(expr *Comparison) (interface{}, error) {
+	left, err := i.evaluate(expr.Operands[0])
+	if err != nil {
+		return nil, err
+	}
+
+	for k, operator := range expr.Operators {
+		right, err := i.evaluate(expr.Operands[k+1])
+		if err != nil {
+			return nil, err
+		}
+		result, err := compare(operator, left, right)
+		if err != nil {
+			return nil, err
+		}
+		if !result {
+			return false, nil
+		}
+		left = right
+	}
+
+	return true, nil
+}
+
+func (i *Interpreter) visitConditionalExpr(expr *Conditional) (interface{}, error) {
+	condition, err := i.evaluate(expr.Condition)
+	if err != nil {
+		return nil, err
 	}
 
-	return function.Call(i, arguments)
+	if i.isTruthy(condition) {
+		return i.evaluate(expr.ThenBranch)
+	}
+	return i.evaluate(expr.ElseBranch)
 }
 
 func (i *Interpreter) visitGetExpr(expr *Get) (interface{}, error) {
@@ -160,13 +225,83 @@ func (i *Interpreter) visitGetExpr(expr *Get) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
-	if _, ok := object.(*TLPSInstance); ok {
-		return object.(*TLPSInstance).Get(expr.Name)
+	switch o := object.(type) {
+	case *TLPSInstance:
+		return o.Get(expr.Name)
+	case *TLPSModule:
+		return o.Get(expr.Name)
+	case *TLPSFile:
+		return o.Get(expr.Name)
+	case *Generator:
+		return o.Get(expr.Name)
+	case *TLPSList:
+		return o.Get(expr.Name)
+	case *TLPSMap:
+		return o.Get(expr.Name)
+	case string:
+		return TLPSString(o).Get(expr.Name)
 	}
 
 	return nil, RuntimeError.New(expr.Name, "Only instances have properties.")
 }
 
+func (i *Interpreter) visitIndexExpr(expr *Index) (interface{}, error) {
+	object, err := i.evaluate(expr.Object)
+	if err != nil {
+		return nil, err
+	}
+	index, err := i.evaluate(expr.Index)
+	if err != nil {
+		return nil, err
+	}
+
+	switch o := object.(type) {
+	case *TLPSList:
+		return o.GetIndex(expr.Bracket, index)
+	case *TLPSMap:
+		return o.GetIndex(expr.Bracket, index)
+	case string:
+		return TLPSString(o).GetIndex(expr.Bracket, index)
+	}
+
+	return nil, RuntimeError.New(expr.Bracket, "Only lists, maps and strings can be indexed.")
+}
+
+func (i *Interpreter) visitInterpolationExpr(expr *Interpolation) (interface{}, error) {
+	var b strings.Builder
+	for _, part := range expr.Parts {
+		v, err := i.evaluate(part)
+		if err != nil {
+			return nil, err
+		}
+		if s, ok := v.(string); ok {
+			b.WriteString(s)
+		} else {
+			b.WriteString(stringfy(v))
+		}
+	}
+
+	return b.String(), nil
+}
+
+func (i *Interpreter) visitKeywordArgExpr(expr *KeywordArg) (interface{}, error) {
+	// keyword argument is bound by visitCallExpr
+	return nil, RuntimeError.New(expr.Name, "Can't use keyword argument here.")
+}
+
+func (i *Interpreter) visitListExpr(expr *List) (interface{}, error) {
+	elements := make([]interface{}, 0, len(expr.Elements))
+	for _, element := range expr.Elements {
+		v, err := i.evaluate(element)
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, v)
+	}
+
+	return NewTLPSList(elements), nil
+}
+
 func (i *Interpreter) visitLiteralExpr(expr *Literal) (interface{}, error) {
 	return expr.Value, nil
 }
@@ -190,6 +325,26 @@ func (i *Interpreter) visitLogicalExpr(expr *Logical) (interface{}, error) {
 	return i.evaluate(expr.Right)
 }
 
+func (i *Interpreter) visitMapExpr(expr *Map) (interface{}, error) {
+	m := NewTLPSMap()
+	for k := range expr.Keys {
+		key, err := i.evaluate(expr.Keys[k])
+		if err != nil {
+			return nil, err
+		}
+		value, err := i.evaluate(expr.Values[k])
+		if err != nil {
+			return nil, err
+		}
+		err = m.SetIndex(expr.Brace, key, value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
 func (i *Interpreter) visitSetExpr(expr *Set) (interface{}, error) {
 	object, err := i.evaluate(expr.Object)
 	if err != nil {
@@ -210,6 +365,64 @@ func (i *Interpreter) visitSetExpr(expr *Set) (interface{}, error) {
 	return value, nil
 }
 
+func (i *Interpreter) visitSetIndexExpr(expr *SetIndex) (interface{}, error) {
+	object, err := i.evaluate(expr.Object)
+	if err != nil {
+		return nil, err
+	}
+	index, err := i.evaluate(expr.Index)
+	if err != nil {
+		return nil, err
+	}
+	value, err := i.evaluate(expr.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	switch o := object.(type) {
+	case *TLPSList:
+		return value, o.SetIndex(expr.Bracket, index, value)
+	case *TLPSMap:
+		return value, o.SetIndex(expr.Bracket, index, value)
+	}
+
+	return nil, RuntimeError.New(expr.Bracket, "Only lists and maps support item assignment.")
+}
+
+func (i *Interpreter) visitSliceExpr(expr *Slice) (interface{}, error) {
+	object, err := i.evaluate(expr.Object)
+	if err != nil {
+		return nil, err
+	}
+	var start, stop interface{}
+	if expr.Start != nil {
+		start, err = i.evaluate(expr.Start)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if expr.Stop != nil {
+		stop, err = i.evaluate(expr.Stop)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch o := object.(type) {
+	case *TLPSList:
+		return o.Slice(expr.Bracket, start, stop)
+	case string:
+		return TLPSString(o).Slice(expr.Bracket, start, stop)
+	}
+
+	return nil, RuntimeError.New(expr.Bracket, "Only lists and strings can be sliced.")
+}
+
+func (i *Interpreter) visitSpreadExpr(expr *Spread) (interface{}, error) {
+	// spread is expanded by visitCallExpr
+	return nil, RuntimeError.New(expr.Star, "Can't use spread here.")
+}
+
 func (i *Interpreter) visitSuperExpr(expr *Super) (interface{}, error) {
 	distance := i.Runtime.Locals[expr]
 	sc, _ := i.Runtime.Environment.GetAt(distance, "super")
@@ -248,7 +461,12 @@ func (i *Interpreter) visitUnaryExpr(expr *Unary) (interface{}, error) {
 		if err != nil {
 			return nil, err
 		}
-		return -right.(float64), nil
+		return negate(right), nil
+	case TildeTT:
+		if !isInteger(right) {
+			return nil, RuntimeError.New(expr.Operator, "Operand must be an integer.")
+		}
+		return normalizeInt(new(big.Int).Not(toBigInt(right))), nil
 	}
 
 	// Unreachable
@@ -264,24 +482,59 @@ func (i *Interpreter) lookUpVariable(name *Token, expr Expr) (interface{}, error
 	if distance, ok := i.Runtime.Locals[expr]; ok {
 		return i.Runtime.Environment.GetAt(distance, name.Lexeme)
 	}
-	return i.Runtime.Globals.Get(name)
+	return i.globals().Get(name)
+}
+
+// globals returns top-level environment of the module which the code being executed belongs to.
+// Builtins are looked up through it.
+func (i *Interpreter) globals() *Environment {
+	env := i.Runtime.Environment
+	for env.Enclosing != nil && env.Enclosing != i.Runtime.Builtins {
+		env = env.Enclosing
+	}
+	return env
 }
 
 func checkNumberOperand(operator *Token, operand interface{}) error {
-	if reflect.ValueOf(operand).Kind() == reflect.Float64 {
+	if isNumber(operand) {
 		return nil
 	}
 	return RuntimeError.New(operator, "Operand must be a number.")
 }
 
 func checkNumberOperands(operator *Token, left interface{}, right interface{}) error {
-	if reflect.ValueOf(left).Kind() == reflect.Float64 &&
-		reflect.ValueOf(right).Kind() == reflect.Float64 {
+	if isNumber(left) && isNumber(right) {
 		return nil
 	}
 	return RuntimeError.New(operator, "Operands must be a number.")
 }
 
+// compare evaluates comparison operator >, >=, < or <=
+func compare(operator *Token, left interface{}, right interface{}) (bool, error) {
+	switch operator.Type {
+	case EqualEqualTT:
+		return isEqual(left, right), nil
+	case BangEqualTT:
+		return !isEqual(left, right), nil
+	}
+
+	err := checkNumberOperands(operator, left, right)
+	if err != nil {
+		return false, err
+	}
+
+	c := compareNumbers(left, right)
+	switch operator.Type {
+	case GreaterTT:
+		return c > 0, nil
+	case GreaterEqualTT:
+		return c >= 0, nil
+	case LessTT:
+		return c < 0, nil
+	}
+	return c <= 0, nil
+}
+
 func (i *Interpreter) isTruthy(object interface{}) bool {
 	if object == nil {
 		return false
@@ -309,6 +562,15 @@ func (i *Interpreter) execute(stmt Stmt) (interface{}, error) {
 	return stmt.Accept(i)
 }
 
+// evaluateIn evaluates expr in given environment
+func (i *Interpreter) evaluateIn(expr Expr, environment *Environment) (interface{}, error) {
+	previous := i.Runtime.Environment
+	defer func() { i.Runtime.Environment = previous }()
+	i.Runtime.Environment = environment
+
+	return i.evaluate(expr)
+}
+
 func (i *Interpreter) executeBlock(statements []Stmt, environment *Environment) (interface{}, error) {
 	previous := i.Runtime.Environment
 	defer func() { i.Runtime.Environment = previous }()
@@ -327,6 +589,10 @@ func (i *Interpreter) visitBlockStmt(stmt *Block) (interface{}, error) {
 	return i.executeBlock(stmt.Statements, NewEnvironment(i.Runtime.Environment))
 }
 
+func (i *Interpreter) visitBreakStmt(stmt *Break) (interface{}, error) {
+	return nil, NewLoopBreak(stmt.Keyword)
+}
+
 func (i *Interpreter) visitClassStmt(stmt *Class) (interface{}, error) {
 	var superclass *TLPSClass = nil
 	if stmt.Superclass != nil {
@@ -350,7 +616,8 @@ func (i *Interpreter) visitClassStmt(stmt *Class) (interface{}, error) {
 
 	methods := make(map[string]*TLPSFunction)
 	for _, method := range stmt.Methods {
-		function := NewTLPSFunction(method, i.Runtime.Environment, method.Name.Lexeme == "init")
+		function := NewTLPSFunction(method, i.Runtime.Environment, method.Name.Lexeme == "init", i.Runtime.File)
+		function.className = stmt.Name.Lexeme
 		methods[method.Name.Lexeme] = function
 	}
 
@@ -365,6 +632,15 @@ func (i *Interpreter) visitClassStmt(stmt *Class) (interface{}, error) {
 	return nil, nil
 }
 
+func (i *Interpreter) visitContinueStmt(stmt *Continue) (interface{}, error) {
+	return nil, NewLoopContinue(stmt.Keyword)
+}
+
+func (i *Interpreter) visitExceptStmt(stmt *Except) (interface{}, error) {
+	// except clause is executed by visitTryStmt
+	return nil, RuntimeError.New(stmt.Keyword, "Unreachable")
+}
+
 func isEqual(a, b interface{}) bool {
 	if a == nil && b == nil {
 		return true
@@ -373,6 +649,42 @@ func isEqual(a, b interface{}) bool {
 		return false
 	}
 
+	if l, ok := a.(*TLPSList); ok {
+		r, ok := b.(*TLPSList)
+		if !ok || l.Len() != r.Len() {
+			return false
+		}
+		for k := range l.Elements {
+			if !isEqual(l.Elements[k], r.Elements[k]) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if l, ok := a.(*TLPSMap); ok {
+		r, ok := b.(*TLPSMap)
+		if !ok || l.Len() != r.Len() {
+			return false
+		}
+		for _, k := range l.Keys() {
+			lv, _ := l.Lookup(k)
+			rv, ok := r.Lookup(k)
+			if !ok || !isEqual(lv, rv) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if isInteger(a) && isInteger(b) || isDecimal(a) && isNumber(b) || isNumber(a) && isDecimal(b) {
+		return compareNumbers(a, b) == 0
+	}
+	if isNumber(a) && isNumber(b) {
+		// 1 == 1.0 is true
+		return toFloat64(a) == toFloat64(b)
+	}
+
 	return a == b
 }
 
@@ -381,8 +693,75 @@ func (i *Interpreter) visitExpressionStmt(stmt *Expression) (interface{}, error)
 	// return nil, nil
 }
 
+func (i *Interpreter) visitLambdaExpr(expr *Lambda) (interface{}, error) {
+	return NewTLPSFunction(expr.Function, i.Runtime.Environment, false, i.Runtime.File), nil
+}
+
+func (i *Interpreter) visitForInStmt(stmt *ForIn) (interface{}, error) {
+	iterable, err := i.evaluate(stmt.Iterable)
+	if err != nil {
+		return nil, err
+	}
+	i.Runtime.CallStack.SetLine(stmt.In.Line)
+	iterator, err := i.getIterator(iterable)
+	if err != nil {
+		return nil, locateError(stmt.In, err)
+	}
+	// iterator made for this loop isn't reachable after the loop, so it's closed when the loop exits early.
+	// Iterator given by variable etc. is left open because it may be consumed later.
+	_, isCall := stmt.Iterable.(*Call)
+	owned := isCall || iterator != iterable
+
+	for {
+		i.Runtime.CallStack.SetLine(stmt.In.Line)
+		v, ok, err := iterator.Next(i)
+		if err != nil {
+			return nil, locateError(stmt.In, err)
+		}
+		if !ok {
+			break
+		}
+
+		// each iteration has its own variable so that closures capture the current value
+		environment := NewEnvironment(i.Runtime.Environment)
+		environment.Define(stmt.Name.Lexeme, v)
+		_, err = i.executeBlock([]Stmt{stmt.Body}, environment)
+		if err != nil {
+			if _, ok := err.(*LoopBreak); ok {
+				if owned {
+					return nil, i.closeIterator(stmt.In, iterator)
+				}
+				break
+			}
+			if _, ok := err.(*LoopContinue); !ok {
+				// generator running this loop is being closed, so it releases the iterator as well
+				if _, exiting := err.(*GeneratorExit); owned || exiting {
+					if cerr := i.closeIterator(stmt.In, iterator); cerr != nil {
+						return nil, cerr
+					}
+				}
+				return nil, err
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// closeIterator closes iterator which loop exits before it's exhausted
+func (i *Interpreter) closeIterator(token *Token, iterator Iterator) error {
+	closer, ok := iterator.(IteratorCloser)
+	if !ok {
+		return nil
+	}
+	if err := closer.Close(i); err != nil {
+		return locateError(token, err)
+	}
+	return nil
+}
+
 func (i *Interpreter) visitFunctionStmt(stmt *Function) (interface{}, error) {
-	function := NewTLPSFunction(stmt, i.Runtime.Environment, false)
+	function := NewTLPSFunction(stmt, i.Runtime.Environment, false, i.Runtime.File)
 	i.Runtime.Environment.Define(stmt.Name.Lexeme, function)
 	return nil, nil
 }
@@ -400,23 +779,162 @@ func (i *Interpreter) visitIfStmt(stmt *If) (interface{}, error) {
 	return nil, nil
 }
 
+// visitImportStmt runs the file in its own environment and binds the module to the name.
+// Each file is executed only once, and importing it again returns the same module.
+func (i *Interpreter) visitImportStmt(stmt *Import) (interface{}, error) {
+	path, err := i.Runtime.FindFile(stmt.Path.Literal.(string))
+	if err != nil {
+		return nil, RuntimeError.New(stmt.Path, err.Error())
+	}
+	path = canonicalPath(path)
+
+	module, ok := i.Runtime.Modules[path]
+	if !ok {
+		source, err := readSource(path)
+		if err != nil {
+			return nil, RuntimeError.New(stmt.Path, err.Error())
+		}
+
+		// register the module before running it so that circular import gets the module being initialized
+		name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		module = NewTLPSModule(name, path, NewEnvironment(i.Runtime.Builtins))
+		i.Runtime.Modules[path] = module
+
+		previousBasePath := i.Runtime.BasePath
+		previousFile := i.Runtime.File
+		previousEnvironment := i.Runtime.Environment
+		i.Runtime.BasePath = filepath.Dir(path)
+		i.Runtime.File = path
+		i.Runtime.Environment = module.environment
+		i.Runtime.CallStack.SetLine(stmt.Path.Line)
+
+		err = i.Runtime.Exec(bytes.NewBuffer(source))
+
+		i.Runtime.BasePath = previousBasePath
+		i.Runtime.File = previousFile
+		i.Runtime.Environment = previousEnvironment
+		if err != nil {
+			// the module can be imported again after the error is fixed
+			delete(i.Runtime.Modules, path)
+			return nil, locateError(stmt.Path, err)
+		}
+	}
+	i.Runtime.Environment.Define(stmt.Name.Lexeme, module)
+
+	return nil, nil
+}
+
+// visitIncludeStmt runs the file in the current environment.
+// A file is included only once into the same environment, and circular include is an error.
 func (i *Interpreter) visitIncludeStmt(stmt *Include) (interface{}, error) {
-	path := filepath.Join(i.Runtime.BasePath, stmt.Path.Literal.(string))
-	source, err := os.ReadFile(path)
+	path, err := i.Runtime.FindFile(stmt.Path.Literal.(string))
+	if err != nil {
+		return nil, RuntimeError.New(stmt.Path, err.Error())
+	}
+	target := canonicalPath(path)
+
+	chain := i.Runtime.Including
+	if len(chain) == 0 {
+		chain = []string{canonicalPath(i.Runtime.File)}
+	}
+	for _, p := range chain {
+		if p == target {
+			return nil, RuntimeError.New(stmt.Path, "Circular include: "+includeChain(append(chain, target))+".")
+		}
+	}
+
+	included, ok := i.Runtime.Included[i.Runtime.Environment]
+	if !ok {
+		included = make(map[string]bool)
+		i.Runtime.Included[i.Runtime.Environment] = included
+	}
+	if included[target] {
+		return nil, nil
+	}
+
+	source, err := readSource(path)
 	if err != nil {
 		return nil, RuntimeError.New(stmt.Path, err.Error())
 	}
 
 	previousBasePath := i.Runtime.BasePath
+	previousFile := i.Runtime.File
 	i.Runtime.BasePath = filepath.Dir(path)
+	i.Runtime.File = path
+	i.Runtime.Including = append(chain, target)
+	i.Runtime.CallStack.SetLine(stmt.Path.Line)
 
-	i.Runtime.Run(bytes.NewBuffer(source))
+	err = i.Runtime.Exec(bytes.NewBuffer(source))
 
 	i.Runtime.BasePath = previousBasePath
+	i.Runtime.File = previousFile
+	i.Runtime.Including = chain
+	if err != nil {
+		return nil, locateError(stmt.Path, err)
+	}
+	// failed include can be retried
+	included[target] = true
 
 	return nil, nil
 }
 
+// canonicalPath returns absolute path of file whose symbolic links are resolved.
+// Path of standard library is returned as it is.
+func canonicalPath(path string) string {
+	if _, ok := embeddedPath(path); ok {
+		return filepath.Clean(path)
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return filepath.Clean(path)
+	}
+	if p, err := filepath.EvalSymlinks(abs); err == nil {
+		return p
+	}
+	return abs
+}
+
+// includeChain formats include chain with paths relative to the directory of the outermost file
+func includeChain(paths []string) string {
+	base := filepath.Dir(paths[0])
+	names := make([]string, 0, len(paths))
+	for _, p := range paths {
+		if rel, err := filepath.Rel(base, p); err == nil {
+			p = rel
+		}
+		names = append(names, p)
+	}
+	return strings.Join(names, " -> ")
+}
+
+func (i *Interpreter) visitRaiseStmt(stmt *Raise) (interface{}, error) {
+	value, err := i.evaluate(stmt.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	// raise Hoge is same as raise Hoge()
+	if klass, ok := value.(*TLPSClass); ok {
+		if message, ok := checkArity(klass, 0); !ok {
+			return nil, RuntimeError.New(stmt.Keyword, message)
+		}
+		value, err = klass.Call(i, []interface{}{})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	instance, ok := value.(*TLPSInstance)
+	if !ok {
+		return nil, RuntimeError.New(stmt.Keyword, "Can only raise instances or classes.")
+	}
+	if _, ok := instance.Fields["line"]; !ok {
+		instance.Fields["line"] = int64(stmt.Keyword.Line)
+	}
+
+	return nil, NewExceptionValue(instance, stmt.Keyword)
+}
+
 func (i *Interpreter) visitReturnStmt(stmt *Return) (interface{}, error) {
 	var value interface{} = nil
 	if stmt.Value != nil {
@@ -430,10 +948,138 @@ func (i *Interpreter) visitReturnStmt(stmt *Return) (interface{}, error) {
 	return nil, NewReturnValue(value)
 }
 
+func (i *Interpreter) visitTryStmt(stmt *Try) (interface{}, error) {
+	_, err := i.execute(stmt.Body)
+	if exception, ok := i.toException(err); ok {
+		err = i.handleException(stmt.Handlers, exception, err)
+	}
+
+	if stmt.FinallyBranch != nil {
+		_, ferr := i.execute(stmt.FinallyBranch)
+		if ferr != nil {
+			return nil, ferr
+		}
+	}
+
+	return nil, err
+}
+
+// handleException executes the first except clause which matches the exception.
+// It returns given err as it is when no clause matches.
+func (i *Interpreter) handleException(handlers []*Except, exception *TLPSInstance, err error) error {
+	for _, handler := range handlers {
+		matched, e := i.matchHandler(handler, exception)
+		if e != nil {
+			return e
+		}
+		if !matched {
+			continue
+		}
+
+		environment := NewEnvironment(i.Runtime.Environment)
+		if handler.Name != nil {
+			environment.Define(handler.Name.Lexeme, exception)
+		}
+		_, e = i.executeBlock([]Stmt{handler.Body}, environment)
+		return e
+	}
+
+	return err
+}
+
+func (i *Interpreter) matchHandler(handler *Except, exception *TLPSInstance) (bool, error) {
+	// bare except catches everything
+	if handler.Typ == nil {
+		return true, nil
+	}
+
+	typ, err := i.evaluate(handler.Typ)
+	if err != nil {
+		return false, err
+	}
+	klass, ok := typ.(*TLPSClass)
+	if !ok {
+		return false, RuntimeError.New(handler.Keyword, "Except type must be a class.")
+	}
+
+	return exception.Klass.IsSubclassOf(klass), nil
+}
+
+// toException converts catchable error into exception instance.
+// Runtime errors are converted into instance of RuntimeError class.
+func (i *Interpreter) toException(err error) (*TLPSInstance, bool) {
+	switch e := err.(type) {
+	case *ExceptionValue:
+		return e.Value, true
+	case *CustomError:
+		if e.typ != RuntimeError.typ {
+			return nil, false
+		}
+		instance := NewTLPSInstance(i.Runtime.RuntimeErrorClass)
+		instance.Fields["message"] = e.message
+		if e.Token != nil {
+			instance.Fields["line"] = int64(e.Token.Line)
+		}
+		return instance, true
+	}
+
+	return nil, false
+}
+
 func (i *Interpreter) visitWhileStmt(stmt *While) (interface{}, error) {
-	v, _ := i.evaluate(stmt.Condition)
-	for ; i.isTruthy(v); v, _ = i.evaluate(stmt.Condition) {
-		i.execute(stmt.Body)
+	for {
+		v, err := i.evaluate(stmt.Condition)
+		if err != nil {
+			return nil, err
+		}
+		if !i.isTruthy(v) {
+			break
+		}
+
+		_, err = i.execute(stmt.Body)
+		if err != nil {
+			if _, ok := err.(*LoopBreak); ok {
+				break
+			}
+			if _, ok := err.(*LoopContinue); !ok {
+				return nil, err
+			}
+		}
+
+		if stmt.Increment != nil {
+			_, err = i.evaluate(stmt.Increment)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func (i *Interpreter) visitYieldStmt(stmt *Yield) (interface{}, error) {
+	var value interface{}
+	if stmt.Value != nil {
+		var err error
+		value, err = i.evaluate(stmt.Value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// generator being closed doesn't yield any more
+	co := i.coroutine
+	if co.closing {
+		return nil, NewGeneratorExit()
+	}
+
+	// suspend until the generator is resumed by Next or Close
+	environment := i.Runtime.Environment
+	co.yield <- generatorResult{value: value}
+	<-co.resume
+	i.Runtime.Environment = environment
+	if co.closing {
+		return nil, NewGeneratorExit()
 	}
 
 	return nil, nil
@@ -462,7 +1108,7 @@ func (i *Interpreter) visitAssignExpr(expr *Assign) (interface{}, error) {
 	if distance, ok := i.Runtime.Locals[expr]; ok {
 		i.Runtime.Environment.AssignAt(distance, expr.Name, value)
 	} else {
-		err := i.Runtime.Globals.Assign(expr.Name, value)
+		err := i.globals().Assign(expr.Name, value)
 		if err != nil {
 			return nil, err
 		}
@@ -486,22 +1132,109 @@ func NewReturnValue(value interface{}) *ReturnValue {
 	return &ReturnValue{Value: value}
 }
 
-func isType(v interface{}, kind reflect.Kind) bool {
-	return reflect.ValueOf(v).Kind() == kind
+// LoopBreak is pseudo error to exit from loop
+type LoopBreak struct {
+	Keyword *Token
 }
 
-func isFloat64(v interface{}) bool {
-	return isType(v, reflect.Float64)
+// Error satisfies error interface
+func (b *LoopBreak) Error() string {
+	return "Break error"
+}
+
+// NewLoopBreak is constructor of LoopBreak
+func NewLoopBreak(keyword *Token) *LoopBreak {
+	return &LoopBreak{Keyword: keyword}
+}
+
+// LoopContinue is pseudo error to go to next iteration of loop
+type LoopContinue struct {
+	Keyword *Token
+}
+
+// Error satisfies error interface
+func (c *LoopContinue) Error() string {
+	return "Continue error"
+}
+
+// NewLoopContinue is constructor of LoopContinue
+func NewLoopContinue(keyword *Token) *LoopContinue {
+	return &LoopContinue{Keyword: keyword}
+}
+
+func isType(v interface{}, kind reflect.Kind) bool {
+	return reflect.ValueOf(v).Kind() == kind
 }
 
 func isString(v interface{}) bool {
 	return isType(v, reflect.String)
 }
 
+// toInt converts integer into int
+func toInt(v interface{}) (int, bool) {
+	n, ok := v.(int64)
+	if !ok || n != int64(int(n)) {
+		return 0, false
+	}
+	return int(n), true
+}
+
+// typeName returns name of type of given value
+func typeName(object interface{}) string {
+	switch o := object.(type) {
+	case nil:
+		return "nil"
+	case bool:
+		return "bool"
+	case int64, *big.Int:
+		return "int"
+	case float64:
+		return "float"
+	case *Decimal:
+		return "decimal"
+	case string:
+		return "string"
+	case *TLPSList:
+		return "list"
+	case *TLPSMap:
+		return "map"
+	case *TLPSRange:
+		return "range"
+	case *Generator:
+		return "generator"
+	case Iterator:
+		return "iterator"
+	case *TLPSClass:
+		return "class"
+	case *TLPSInstance:
+		return o.Klass.Name
+	case *TLPSModule:
+		return "module"
+	case *TLPSFile:
+		return "file"
+	case TLPSCallable:
+		return "function"
+	}
+
+	return fmt.Sprintf("%T", object)
+}
+
+// repr returns string representation of value used in containers
+func repr(object interface{}) string {
+	if s, ok := object.(string); ok {
+		return strconv.Quote(s)
+	}
+
+	return stringfy(object)
+}
+
 func stringfy(object interface{}) string {
 	if object == nil {
 		return "nil"
 	}
+	if f, ok := object.(float64); ok {
+		return formatFloat(f)
+	}
 
 	return fmt.Sprint(object)
 }
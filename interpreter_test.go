@@ -1,6 +1,10 @@
 package tlps_test
 
 import (
+	"bufio"
+	"bytes"
+	"math"
+	"strings"
 	"testing"
 
 	"github.com/goropikari/tlps"
@@ -36,6 +40,20 @@ func TestInterpreter(t *testing.T) {
 				tlps.NewExpression(tlps.NewBinary(tlps.NewLiteral(2.0), tlps.NewToken(tlps.SlashTT, "/", nil, 1), tlps.NewLiteral(4.0))),
 			},
 		},
+		{
+			name:     "4 / 2",
+			expected: "2.0",
+			given: []tlps.Stmt{
+				tlps.NewExpression(tlps.NewBinary(tlps.NewLiteral(int64(4)), tlps.NewToken(tlps.SlashTT, "/", nil, 1), tlps.NewLiteral(int64(2)))),
+			},
+		},
+		{
+			name:     "int overflow",
+			expected: "9223372036854775808",
+			given: []tlps.Stmt{
+				tlps.NewExpression(tlps.NewBinary(tlps.NewLiteral(int64(math.MaxInt64)), tlps.NewToken(tlps.PlusTT, "+", nil, 1), tlps.NewLiteral(int64(1)))),
+			},
+		},
 		{
 			name:     "string + string",
 			expected: "foo bar",
@@ -56,6 +74,8 @@ func TestInterpreter(t *testing.T) {
 						tlps.NewToken(tlps.IdentifierTT, "x", nil, 1),
 						tlps.NewToken(tlps.IdentifierTT, "y", nil, 1),
 					},
+					nil,
+					nil,
 					[]tlps.Stmt{
 						tlps.NewReturn(
 							tlps.NewToken(tlps.ReturnTT, "return", nil, 2),
@@ -72,8 +92,8 @@ func TestInterpreter(t *testing.T) {
 						tlps.NewVariable(tlps.NewToken(tlps.IdentifierTT, "f", nil, 3)),
 						tlps.NewToken(tlps.LeftParenTT, "(", nil, 3),
 						[]tlps.Expr{
-							tlps.NewLiteral(float64(11)),
-							tlps.NewLiteral(float64(2)),
+							tlps.NewLiteral(int64(11)),
+							tlps.NewLiteral(int64(2)),
 						},
 					),
 				),
@@ -96,6 +116,8 @@ func TestInterpreter(t *testing.T) {
 							[]*tlps.Token{
 								tlps.NewToken(tlps.IdentifierTT, "x", nil, 2),
 							},
+							nil,
+							nil,
 							[]tlps.Stmt{
 								tlps.NewExpression(
 									tlps.NewSet(
@@ -147,6 +169,8 @@ func TestInterpreter(t *testing.T) {
 							[]*tlps.Token{
 								tlps.NewToken(tlps.IdentifierTT, "x", nil, 2),
 							},
+							nil,
+							nil,
 							[]tlps.Stmt{
 								tlps.NewExpression(
 									tlps.NewSet(
@@ -172,6 +196,8 @@ func TestInterpreter(t *testing.T) {
 						tlps.NewFunction(
 							tlps.NewToken(tlps.PassTT, "pass", nil, 5),
 							[]*tlps.Token{},
+							nil,
+							nil,
 							[]tlps.Stmt{},
 						).(*tlps.Function),
 					},
@@ -211,19 +237,25 @@ func TestInterpreter(t *testing.T) {
 				tlps.NewFunction(
 					tlps.NewToken(tlps.IdentifierTT, "f", nil, 1),
 					[]*tlps.Token{},
+					nil,
+					nil,
 					[]tlps.Stmt{
 						tlps.NewVar(
 							tlps.NewToken(tlps.IdentifierTT, "a", nil, 2),
-							tlps.NewLiteral(10.0),
+							tlps.NewLiteral(int64(10)),
 						),
 
 						tlps.NewFunction(
 							tlps.NewToken(tlps.IdentifierTT, "g", nil, 3),
 							[]*tlps.Token{},
+							nil,
+							nil,
 							[]tlps.Stmt{
 								tlps.NewFunction(
 									tlps.NewToken(tlps.IdentifierTT, "h", nil, 4),
 									[]*tlps.Token{},
+									nil,
+									nil,
 									[]tlps.Stmt{
 										tlps.NewReturn(
 											tlps.NewToken(tlps.ReturnTT, "return", nil, 2),
@@ -241,7 +273,7 @@ func TestInterpreter(t *testing.T) {
 								),
 								tlps.NewVar(
 									tlps.NewToken(tlps.IdentifierTT, "a", nil, 7),
-									tlps.NewLiteral(123.0),
+									tlps.NewLiteral(int64(123)),
 								),
 								tlps.NewVar(
 									tlps.NewToken(tlps.IdentifierTT, "y", nil, 8),
@@ -284,6 +316,58 @@ func TestInterpreter(t *testing.T) {
 				),
 			},
 		},
+		{
+			name:     "assignment to global doesn't stop resolution",
+			expected: "2",
+			given: []tlps.Stmt{
+				// var n = 1
+				// fun setN():
+				//   var m = 0
+				//   n = 2
+				//   m = n
+				//   return m
+				// setN()
+				tlps.NewVar(
+					tlps.NewToken(tlps.IdentifierTT, "n", nil, 1),
+					tlps.NewLiteral(int64(1)),
+				),
+				tlps.NewFunction(
+					tlps.NewToken(tlps.IdentifierTT, "setN", nil, 2),
+					[]*tlps.Token{},
+					nil,
+					nil,
+					[]tlps.Stmt{
+						tlps.NewVar(
+							tlps.NewToken(tlps.IdentifierTT, "m", nil, 3),
+							tlps.NewLiteral(int64(0)),
+						),
+						tlps.NewExpression(
+							tlps.NewAssign(
+								tlps.NewToken(tlps.IdentifierTT, "n", nil, 4),
+								tlps.NewLiteral(int64(2)),
+							),
+						),
+						tlps.NewExpression(
+							tlps.NewAssign(
+								tlps.NewToken(tlps.IdentifierTT, "m", nil, 5),
+								tlps.NewVariable(tlps.NewToken(tlps.IdentifierTT, "n", nil, 5)),
+							),
+						),
+						tlps.NewReturn(
+							tlps.NewToken(tlps.ReturnTT, "return", nil, 6),
+							tlps.NewVariable(tlps.NewToken(tlps.IdentifierTT, "m", nil, 6)),
+						),
+					},
+				),
+				tlps.NewExpression(
+					tlps.NewCall(
+						tlps.NewVariable(tlps.NewToken(tlps.IdentifierTT, "setN", nil, 7)),
+						tlps.NewToken(tlps.LeftParenTT, "(", nil, 7),
+						[]tlps.Expr{},
+					),
+				),
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -301,6 +385,7 @@ func TestInterpreter(t *testing.T) {
 func TestInterpreter_Error(t *testing.T) {
 	r := tlps.NewRuntime()
 	plus := tlps.NewToken(tlps.PlusTT, "+", nil, 1)
+	bracket := tlps.NewToken(tlps.LeftBracketTT, "[", nil, 1)
 
 	var tests = []struct {
 		name     string
@@ -316,6 +401,20 @@ func TestInterpreter_Error(t *testing.T) {
 				tlps.NewExpression(tlps.NewBinary(tlps.NewLiteral(1.5), plus, tlps.NewLiteral("bar"))),
 			},
 		},
+		{
+			name:     "list index out of range",
+			expected: "nil",
+			err:      tlps.RuntimeError.New(bracket, "List index out of range."),
+			given: []tlps.Stmt{
+				tlps.NewExpression(
+					tlps.NewIndex(
+						tlps.NewList(bracket, []tlps.Expr{tlps.NewLiteral(int64(1))}),
+						bracket,
+						tlps.NewLiteral(int64(-2)),
+					),
+				),
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -328,3 +427,227 @@ func TestInterpreter_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestInterpreter_Loop(t *testing.T) {
+	var tests = []struct {
+		name     string
+		expected interface{}
+		err      error
+		code     string
+	}{
+		{
+			name:     "return from while",
+			expected: "3",
+			code: `fun f():
+    var i = 0
+    while true:
+        i = i + 1
+        if i == 3:
+            return i
+    return -1
+f()
+`,
+		},
+		{
+			name:     "return from for",
+			expected: "2",
+			code: `fun f(xs):
+    for var i = 0; i < xs.len(); i = i + 1:
+        if xs[i] == "b":
+            return i
+f(["x", "y", "b", "b"])
+`,
+		},
+		{
+			name:     "return from nested loops",
+			expected: "6",
+			code: `fun f():
+    for var i = 0; i < 5; i = i + 1:
+        var j = 0
+        while j < 5:
+            if i * j == 6:
+                return i + j + 1
+            j = j + 1
+    return nil
+f()
+`,
+		},
+		{
+			name:     "error in condition",
+			expected: "nil",
+			err:      tlps.RuntimeError.New(tlps.NewToken(tlps.LessTT, "<", nil, 2), "Operands must be a number."),
+			code: `var i = 0
+while i < "10":
+    i = i + 1
+`,
+		},
+		{
+			name:     "error in body",
+			expected: "nil",
+			err:      tlps.RuntimeError.New(tlps.NewToken(tlps.IdentifierTT, "y", nil, 4), "Undefined variable 'y'."),
+			code: `fun f():
+    var x = 0
+    while true:
+        x = y
+f()
+`,
+		},
+		{
+			name:     "error in increment",
+			expected: "nil",
+			err:      tlps.RuntimeError.New(tlps.NewToken(tlps.MinusTT, "-", nil, 1), "Operands must be a number."),
+			code: `for var i = 0; i < 3; i = i - "1":
+    pass
+`,
+		},
+		{
+			name:     "error in initializer",
+			expected: "nil",
+			err:      tlps.RuntimeError.New(tlps.NewToken(tlps.LeftBracketTT, "[", nil, 3), "List index out of range."),
+			code: `class Hoge:
+    init():
+        this.x = [][0]
+Hoge()
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			r := tlps.NewRuntime()
+			tokens := tlps.NewScanner(r, bytes.NewBufferString(tt.code)).ScanTokens()
+			stmts, err := tlps.NewParser(r, tokens).Parse()
+			assert.NoError(t, err)
+			interpreter := tlps.NewInterpreter(r)
+			tlps.NewResolver(r, interpreter).ResolveStmts(stmts)
+			actual, err := interpreter.Interpret(stmts)
+			assert.Equal(t, tt.expected, actual)
+			if tt.err == nil {
+				assert.NoError(t, err)
+			} else {
+				// error raised in function has traceback
+				assert.EqualError(t, err, tt.err.Error())
+				assert.Equal(t, tt.err.(*tlps.CustomError).Token.Line, err.(*tlps.CustomError).Token.Line)
+			}
+		})
+	}
+}
+
+func TestInterpreter_Traceback(t *testing.T) {
+	code := `fun f(n):
+    if n == 0:
+        return [][0]
+    return f(n - 1)
+
+class Hoge:
+    init():
+        this.x = f(2)
+Hoge()
+`
+	r := tlps.NewRuntime()
+	r.File = "hoge.tlps"
+	r.CallStack.Push(tlps.NewCallFrame("<module>", r.File, 0))
+	tokens := tlps.NewScanner(r, bytes.NewBufferString(code)).ScanTokens()
+	stmts, err := tlps.NewParser(r, tokens).Parse()
+	assert.NoError(t, err)
+	interpreter := tlps.NewInterpreter(r)
+	tlps.NewResolver(r, interpreter).ResolveStmts(stmts)
+	_, err = interpreter.Interpret(stmts)
+
+	expected := tlps.Traceback{
+		{Name: "<module>", File: "hoge.tlps", Line: 9},
+		{Name: "Hoge.init", File: "hoge.tlps", Line: 8},
+		{Name: "f", File: "hoge.tlps", Line: 4},
+		{Name: "f", File: "hoge.tlps", Line: 4},
+		{Name: "f", File: "hoge.tlps", Line: 3},
+	}
+	assert.Equal(t, expected, err.(*tlps.CustomError).Trace)
+}
+
+func TestTraceback_String(t *testing.T) {
+	var tests = []struct {
+		name     string
+		expected string
+		given    tlps.Traceback
+	}{
+		{
+			name: "builtin and native frames are hidden",
+			expected: `Traceback (most recent call last):
+  File "hoge.tlps", line 4, in <module>
+  File "hoge.tlps", line 2, in gen`,
+			given: tlps.Traceback{
+				{Name: "<module>", File: "hoge.tlps", Line: 4},
+				{Name: "map", File: "<native>", Line: 0},
+				{Name: "next", File: "<builtin>", Line: 0},
+				{Name: "gen", File: "hoge.tlps", Line: 2},
+			},
+		},
+		{
+			name: "repeated frames are collapsed",
+			expected: `Traceback (most recent call last):
+  File "hoge.tlps", line 5, in <module>
+  File "hoge.tlps", line 2, in f
+  File "hoge.tlps", line 2, in f
+  File "hoge.tlps", line 2, in f
+  [Previous line repeated 1 more times]`,
+			given: tlps.Traceback{
+				{Name: "<module>", File: "hoge.tlps", Line: 5},
+				{Name: "f", File: "hoge.tlps", Line: 2},
+				{Name: "f", File: "hoge.tlps", Line: 2},
+				{Name: "f", File: "hoge.tlps", Line: 2},
+				{Name: "f", File: "hoge.tlps", Line: 2},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.given.String())
+		})
+	}
+}
+
+func TestInterpreter_Stdin(t *testing.T) {
+	var tests = []struct {
+		name     string
+		expected interface{}
+		input    string
+		code     string
+	}{
+		{
+			name:     "input removes line terminator",
+			expected: `["foo", "bar", nil]`,
+			input:    "foo\r\nbar",
+			code:     "[input(), input(), input()]\n",
+		},
+		{
+			name:     "readline keeps line terminator",
+			expected: `["foo\n", "bar", ""]`,
+			input:    "foo\nbar",
+			code:     "[stdin.readline(), stdin.readline(), stdin.readline()]\n",
+		},
+		{
+			name:     "input and stdin share buffer",
+			expected: `["a", ["b", "c"]]`,
+			input:    "a\nb\nc\n",
+			code:     "[input(), stdin.lines()]\n",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			r := tlps.NewRuntime()
+			r.Stdin = bufio.NewReader(strings.NewReader(tt.input))
+			tokens := tlps.NewScanner(r, bytes.NewBufferString(tt.code)).ScanTokens()
+			stmts, err := tlps.NewParser(r, tokens).Parse()
+			assert.NoError(t, err)
+			interpreter := tlps.NewInterpreter(r)
+			tlps.NewResolver(r, interpreter).ResolveStmts(stmts)
+			actual, err := interpreter.Interpret(stmts)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,284 @@
+package tlps
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Iterator is interface of iterator consumed by for-in loop and next()
+type Iterator interface {
+	// Next returns next value. ok is false when the iterator is exhausted.
+	Next(i *Interpreter) (value interface{}, ok bool, err error)
+}
+
+// IteratorCloser is iterator which needs to be closed when loop exits before it's exhausted
+type IteratorCloser interface {
+	Close(i *Interpreter) error
+}
+
+// listIterator iterates elements of list.
+// Elements appended during the iteration are also visited.
+type listIterator struct {
+	list  *TLPSList
+	index int
+}
+
+func (it *listIterator) Next(i *Interpreter) (interface{}, bool, error) {
+	if it.index >= it.list.Len() {
+		return nil, false, nil
+	}
+	v := it.list.Elements[it.index]
+	it.index++
+
+	return v, true, nil
+}
+
+func (it *listIterator) String() string {
+	return "<list iterator>"
+}
+
+// sliceIterator iterates snapshot of values such as characters of string and keys of map
+type sliceIterator struct {
+	name   string
+	values []interface{}
+	index  int
+}
+
+func (it *sliceIterator) Next(i *Interpreter) (interface{}, bool, error) {
+	if it.index >= len(it.values) {
+		return nil, false, nil
+	}
+	v := it.values[it.index]
+	it.index++
+
+	return v, true, nil
+}
+
+func (it *sliceIterator) String() string {
+	return "<" + it.name + " iterator>"
+}
+
+// rangeIterator iterates integers of range
+type rangeIterator struct {
+	next int64
+	stop int64
+	step int64
+	done bool
+}
+
+func (it *rangeIterator) Next(i *Interpreter) (interface{}, bool, error) {
+	if it.done || it.step > 0 && it.next >= it.stop || it.step < 0 && it.next <= it.stop {
+		return nil, false, nil
+	}
+	v := it.next
+	if it.step > 0 && it.next > math.MaxInt64-it.step || it.step < 0 && it.next < math.MinInt64-it.step {
+		it.done = true
+	} else {
+		it.next += it.step
+	}
+
+	return v, true, nil
+}
+
+func (it *rangeIterator) String() string {
+	return "<range iterator>"
+}
+
+// instanceIterator iterates instance of user class by calling its next method
+// until it raises StopIteration.
+type instanceIterator struct {
+	instance *TLPSInstance
+}
+
+func (it *instanceIterator) Next(i *Interpreter) (interface{}, bool, error) {
+	v, err := i.callMethod(it.instance, "next")
+	if e, ok := err.(*ExceptionValue); ok && e.Value.Klass.IsSubclassOf(i.Runtime.StopIterationClass) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	return v, true, nil
+}
+
+func (it *instanceIterator) String() string {
+	return it.instance.String()
+}
+
+// getIterator returns iterator of iterable object.
+// Instance of user class is iterable if it has iter method which returns iterator,
+// that is, builtin iterator or instance which has next method.
+func (i *Interpreter) getIterator(object interface{}) (Iterator, error) {
+	switch o := object.(type) {
+	case Iterator:
+		return o, nil
+	case *TLPSList:
+		return &listIterator{list: o}, nil
+	case string:
+		values := make([]interface{}, 0, len(o))
+		for _, c := range o {
+			values = append(values, string(c))
+		}
+		return &sliceIterator{name: "string", values: values}, nil
+	case *TLPSMap:
+		keys := make([]interface{}, len(o.Keys()))
+		copy(keys, o.Keys())
+		return &sliceIterator{name: "map", values: keys}, nil
+	case *TLPSRange:
+		return &rangeIterator{next: o.start, stop: o.stop, step: o.step}, nil
+	case *TLPSInstance:
+		if !hasMethod(o, "iter") {
+			break
+		}
+		v, err := i.callMethod(o, "iter")
+		if err != nil {
+			return nil, err
+		}
+		switch it := v.(type) {
+		case Iterator:
+			return it, nil
+		case *TLPSInstance:
+			if hasMethod(it, "next") {
+				return &instanceIterator{instance: it}, nil
+			}
+		}
+		return nil, errors.New("iter() returned non-iterator of type " + typeName(v) + ".")
+	}
+
+	return nil, errors.New("Can't iterate over " + typeName(object) + ".")
+}
+
+// hasMethod checks that instance has method or callable field of given name
+func hasMethod(instance *TLPSInstance, name string) bool {
+	if v, ok := instance.Fields[name]; ok {
+		_, ok := v.(TLPSCallable)
+		return ok
+	}
+	method, _ := instance.Klass.FindMethod(name)
+	return method != nil
+}
+
+// callMethod calls method of instance without arguments
+func (i *Interpreter) callMethod(instance *TLPSInstance, name string) (interface{}, error) {
+	method, err := instance.Get(NewToken(IdentifierTT, name, nil, 0))
+	if err != nil {
+		return nil, err
+	}
+	callable, ok := method.(TLPSCallable)
+	if !ok {
+		return nil, errors.New("Can only call functions and classes.")
+	}
+	if message, ok := checkArity(callable, 0); !ok {
+		return nil, errors.New(message)
+	}
+
+	return callable.Call(i, []interface{}{})
+}
+
+// stopIteration returns StopIteration exception raised by builtin.
+// Its location is given by visitCallExpr.
+func (i *Interpreter) stopIteration() error {
+	instance := NewTLPSInstance(i.Runtime.StopIterationClass)
+	instance.Fields["message"] = ""
+	return NewExceptionValue(instance, nil)
+}
+
+// locateError gives location to error returned by builtin which doesn't know where it is called
+func locateError(token *Token, err error) error {
+	switch e := err.(type) {
+	case *CustomError:
+	case *ExceptionValue:
+		if e.Token == nil {
+			e.Token = token
+			e.Value.Fields["line"] = int64(token.Line)
+		}
+	default:
+		return RuntimeError.New(token, err.Error())
+	}
+
+	return err
+}
+
+// builtinIter is iter(iterable) which returns iterator
+func builtinIter(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	return i.getIterator(arguments[0])
+}
+
+// builtinNext is next(iterator) which returns next value of iterator.
+// It raises StopIteration if the iterator is exhausted.
+func builtinNext(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	switch it := arguments[0].(type) {
+	case Iterator:
+		v, ok, err := it.Next(i)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, i.stopIteration()
+		}
+		return v, nil
+	case *TLPSInstance:
+		if hasMethod(it, "next") {
+			return i.callMethod(it, "next")
+		}
+	}
+
+	return nil, errors.New(typeName(arguments[0]) + " is not an iterator.")
+}
+
+// TLPSRange is sequence of integers made by range()
+type TLPSRange struct {
+	start int64
+	stop  int64
+	step  int64
+}
+
+func (r *TLPSRange) String() string {
+	if r.step == 1 {
+		return fmt.Sprintf("range(%v, %v)", r.start, r.stop)
+	}
+	return fmt.Sprintf("range(%v, %v, %v)", r.start, r.stop, r.step)
+}
+
+// RangeFunc is native function to make range
+// ex. range(10), range(1, 10), range(10, 0, -2)
+type RangeFunc struct{}
+
+// NewRangeFunc is constructor of RangeFunc
+func NewRangeFunc() *RangeFunc {
+	return &RangeFunc{}
+}
+
+// Arity returns arity of range function
+func (rf *RangeFunc) Arity() (int, int) {
+	return 1, 3
+}
+
+// Call makes range from stop, start and stop, or start, stop and step
+func (rf *RangeFunc) Call(arguments []interface{}) (interface{}, error) {
+	args := make([]int64, 0, len(arguments))
+	for _, v := range arguments {
+		n, ok := v.(int64)
+		if !ok {
+			return nil, errors.New("Arguments of range must be integers.")
+		}
+		args = append(args, n)
+	}
+
+	r := &TLPSRange{start: 0, step: 1}
+	switch len(args) {
+	case 1:
+		r.stop = args[0]
+	case 2:
+		r.start, r.stop = args[0], args[1]
+	case 3:
+		r.start, r.stop, r.step = args[0], args[1], args[2]
+	}
+	if r.step == 0 {
+		return nil, errors.New("Step of range must not be zero.")
+	}
+
+	return r, nil
+}
@@ -3,27 +3,39 @@ package tlps
 // NativeCallable is interface to call native function
 type NativeCallable interface {
 	Call([]interface{}) (interface{}, error)
-	Arity() int
+	// Arity returns minimum and maximum number of arguments.
+	// max -1 means that the function accepts any number of arguments.
+	Arity() (int, int)
 }
 
 // NativeFunction is struct for native function
 type NativeFunction struct {
+	Name     string
 	Function NativeCallable
 }
 
 // NewNativeFunction is constructor of NativeFunction
-func NewNativeFunction(function NativeCallable) TLPSCallable {
+func NewNativeFunction(name string, function NativeCallable) TLPSCallable {
 	return &NativeFunction{
+		Name:     name,
 		Function: function,
 	}
 }
 
 // Call calls native function
 func (nf *NativeFunction) Call(i *Interpreter, args []interface{}) (interface{}, error) {
+	callStack := i.Runtime.CallStack
+	callStack.Push(NewCallFrame(nf.Name, "<native>", 0))
+	defer callStack.Pop()
+
 	return nf.Function.Call(args)
 }
 
 // Arity returns arity of native function
-func (nf *NativeFunction) Arity() int {
+func (nf *NativeFunction) Arity() (int, int) {
 	return nf.Function.Arity()
 }
+
+func (nf *NativeFunction) String() string {
+	return "<native fn " + nf.Name + ">"
+}
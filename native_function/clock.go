@@ -13,8 +13,8 @@ func NewClockFunc() *ClockFunc {
 }
 
 // Arity returns 0
-func (cf *ClockFunc) Arity() int {
-	return 0
+func (cf *ClockFunc) Arity() (int, int) {
+	return 0, 0
 }
 
 // Call return now unix time
@@ -2,6 +2,8 @@ package native_function
 
 import (
 	"errors"
+	"math"
+	"math/big"
 	"os"
 )
 
@@ -15,19 +17,30 @@ func NewExitFunc() *ExitFunc {
 	return &ExitFunc{}
 }
 
-func (ef *ExitFunc) Arity() int {
-	return 1
+func (ef *ExitFunc) Arity() (int, int) {
+	return 1, 1
 }
 
 func (ef *ExitFunc) Call(arguments []interface{}) (interface{}, error) {
-	if len(arguments) == 1 {
-		if v, ok := arguments[0].(float64); ok {
-			os.Exit(int(v))
-		}
-		if v, ok := arguments[0].(int); ok {
-			os.Exit(v)
+	var status float64
+	switch v := arguments[0].(type) {
+	case int64:
+		status = float64(v)
+	case float64:
+		// integral float such as 1.0 is accepted
+		if v != math.Trunc(v) {
+			return nil, errors.New("Exit status must be an integer.")
 		}
+		status = v
+	case *big.Int:
+		return nil, errors.New("Exit status is too large.")
+	default:
+		return nil, errors.New("Exit status must be an integer.")
+	}
+	if status < math.MinInt32 || status > math.MaxInt32 {
+		return nil, errors.New("Exit status is too large.")
 	}
 
-	return nil, errors.New("invalid type")
+	os.Exit(int(status))
+	return nil, nil
 }
@@ -2,17 +2,39 @@ package native_function
 
 import "fmt"
 
-type PrintFunc struct{}
+type PrintFunc struct {
+	stringfy func(interface{}) string
+}
 
-func NewPrintFunc() *PrintFunc {
-	return &PrintFunc{}
+// NewPrintFunc is constructor of PrintFunc.
+// stringfy converts a value other than string into its representation.
+func NewPrintFunc(stringfy func(interface{}) string) *PrintFunc {
+	return &PrintFunc{stringfy: stringfy}
 }
 
-func (pf *PrintFunc) Arity() int {
-	return -1
+func (pf *PrintFunc) Arity() (int, int) {
+	return 0, -1
 }
 
 func (pf *PrintFunc) Call(arguments []interface{}) (interface{}, error) {
-	fmt.Print(arguments...)
+	args := make([]interface{}, len(arguments))
+	for i, v := range arguments {
+		if s, ok := v.(string); ok {
+			args[i] = s
+		} else {
+			// wrap by Stringer so that fmt.Print puts spaces between operands as before
+			args[i] = value{v, pf.stringfy}
+		}
+	}
+	fmt.Print(args...)
 	return nil, nil
 }
+
+type value struct {
+	v        interface{}
+	stringfy func(interface{}) string
+}
+
+func (v value) String() string {
+	return v.stringfy(v.v)
+}
@@ -0,0 +1,358 @@
+package tlps
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// Numbers are represented by following types.
+//   int64    : integer
+//   *big.Int : integer which doesn't fit in int64
+//   float64  : floating point number
+//   *Decimal : exact base-10 number made by decimal()
+// Integer result of operations is stored as int64 whenever it fits,
+// so that a value has only one representation.
+
+// normalizeInt demotes big integer to int64 if it fits
+func normalizeInt(x *big.Int) interface{} {
+	if x.IsInt64() {
+		return x.Int64()
+	}
+	return x
+}
+
+func isNumber(v interface{}) bool {
+	switch v.(type) {
+	case int64, *big.Int, float64, *Decimal:
+		return true
+	}
+	return false
+}
+
+func isInteger(v interface{}) bool {
+	switch v.(type) {
+	case int64, *big.Int:
+		return true
+	}
+	return false
+}
+
+// toBigInt converts integer to big.Int
+func toBigInt(v interface{}) *big.Int {
+	switch n := v.(type) {
+	case int64:
+		return big.NewInt(n)
+	case *big.Int:
+		return n
+	}
+	return nil
+}
+
+// toFloat64 converts number to float64
+func toFloat64(v interface{}) float64 {
+	switch n := v.(type) {
+	case int64:
+		return float64(n)
+	case *big.Int:
+		f, _ := new(big.Float).SetInt(n).Float64()
+		return f
+	case float64:
+		return n
+	case *Decimal:
+		f, _ := n.Rat().Float64()
+		return f
+	}
+	return math.NaN()
+}
+
+// toRat converts number to big.Rat. ok is false if v is infinity or NaN.
+func toRat(v interface{}) (*big.Rat, bool) {
+	switch n := v.(type) {
+	case int64, *big.Int:
+		return new(big.Rat).SetInt(toBigInt(n)), true
+	case float64:
+		if math.IsInf(n, 0) || math.IsNaN(n) {
+			return nil, false
+		}
+		return new(big.Rat).SetFloat64(n), true
+	case *Decimal:
+		return n.Rat(), true
+	}
+	return nil, false
+}
+
+// compareNumbers returns -1, 0 or 1 as left is less than, equal to, or greater than right
+func compareNumbers(left interface{}, right interface{}) int {
+	if isInteger(left) && isInteger(right) {
+		if l, ok := left.(int64); ok {
+			if r, ok := right.(int64); ok {
+				switch {
+				case l < r:
+					return -1
+				case l > r:
+					return 1
+				}
+				return 0
+			}
+		}
+		return toBigInt(left).Cmp(toBigInt(right))
+	}
+	if isDecimal(left) || isDecimal(right) {
+		// compare exactly since decimal("0.1") != 0.1
+		l, lok := toRat(left)
+		r, rok := toRat(right)
+		if lok && rok {
+			return l.Cmp(r)
+		}
+	}
+
+	l, r := toFloat64(left), toFloat64(right)
+	switch {
+	case l < r:
+		return -1
+	case l > r:
+		return 1
+	}
+	return 0
+}
+
+// arithmetic evaluates binary arithmetic operation of numbers.
+// If either operand is float, the result is float. Otherwise the result is integer
+// except true division and negative exponent.
+func arithmetic(operator *Token, left interface{}, right interface{}) (interface{}, error) {
+	switch operator.Type {
+	case SlashTT, SlashSlashTT, PercentTT:
+		if isZero(right) {
+			return nil, RuntimeError.New(operator, "Division by zero.")
+		}
+	}
+
+	if isDecimal(left) || isDecimal(right) {
+		return decimalArithmetic(operator, left, right)
+	}
+	if operator.Type == StarStarTT {
+		if isZero(left) && isNegative(right) {
+			return nil, RuntimeError.New(operator, "Division by zero.")
+		}
+		if isInteger(left) && isInteger(right) && intPowDigits(toBigInt(left), toBigInt(right)) > maxPowDigits {
+			return nil, RuntimeError.New(operator, "Exponent is too large.")
+		}
+	}
+	if !isInteger(left) || !isInteger(right) {
+		return floatArithmetic(operator, toFloat64(left), toFloat64(right)), nil
+	}
+
+	l, lok := left.(int64)
+	r, rok := right.(int64)
+	if lok && rok {
+		if v, ok := intArithmetic(operator, l, r); ok {
+			return v, nil
+		}
+	}
+
+	return bigArithmetic(operator, toBigInt(left), toBigInt(right)), nil
+}
+
+func isNegative(v interface{}) bool {
+	switch n := v.(type) {
+	case int64:
+		return n < 0
+	case *big.Int:
+		return n.Sign() < 0
+	case float64:
+		return n < 0
+	}
+	return false
+}
+
+// intPowDigits estimates the number of digits of l ** r
+func intPowDigits(l *big.Int, r *big.Int) float64 {
+	if r.Sign() <= 0 || l.BitLen() <= 1 {
+		return 0
+	}
+	if !r.IsInt64() {
+		return math.Inf(1)
+	}
+	return float64(l.BitLen()-1) * math.Log10(2) * float64(r.Int64())
+}
+
+func isZero(v interface{}) bool {
+	switch n := v.(type) {
+	case int64:
+		return n == 0
+	case float64:
+		return n == 0
+	case *Decimal:
+		return n.Sign() == 0
+	}
+	return false
+}
+
+func floatArithmetic(operator *Token, l float64, r float64) float64 {
+	switch operator.Type {
+	case PlusTT:
+		return l + r
+	case MinusTT:
+		return l - r
+	case StarTT:
+		return l * r
+	case SlashTT:
+		return l / r
+	case SlashSlashTT:
+		return math.Floor(l / r)
+	case PercentTT:
+		// result has the same sign as divisor
+		m := math.Mod(l, r)
+		if m != 0 && (m < 0) != (r < 0) {
+			m += r
+		}
+		return m
+	case StarStarTT:
+		return math.Pow(l, r)
+	}
+	return math.NaN()
+}
+
+// intArithmetic evaluates operation of int64.
+// ok is false if the result overflows or isn't integer.
+func intArithmetic(operator *Token, l int64, r int64) (interface{}, bool) {
+	switch operator.Type {
+	case PlusTT:
+		v := l + r
+		if (v > l) == (r > 0) {
+			return v, true
+		}
+	case MinusTT:
+		v := l - r
+		if (v < l) == (r > 0) {
+			return v, true
+		}
+	case StarTT:
+		if l == 0 || r == 0 {
+			return int64(0), true
+		}
+		v := l * r
+		if v/r == l && !(l == -1 && r == math.MinInt64) && !(r == -1 && l == math.MinInt64) {
+			return v, true
+		}
+	case SlashTT:
+		return float64(l) / float64(r), true
+	case SlashSlashTT:
+		if l == math.MinInt64 && r == -1 {
+			return nil, false
+		}
+		q := l / r
+		if l%r != 0 && (l < 0) != (r < 0) {
+			q--
+		}
+		return q, true
+	case PercentTT:
+		if r == -1 {
+			return int64(0), true
+		}
+		m := l % r
+		if m != 0 && (m < 0) != (r < 0) {
+			m += r
+		}
+		return m, true
+	case StarStarTT:
+		if r < 0 {
+			return math.Pow(float64(l), float64(r)), true
+		}
+	}
+	return nil, false
+}
+
+func bigArithmetic(operator *Token, l *big.Int, r *big.Int) interface{} {
+	v := new(big.Int)
+	switch operator.Type {
+	case PlusTT:
+		v.Add(l, r)
+	case MinusTT:
+		v.Sub(l, r)
+	case StarTT:
+		v.Mul(l, r)
+	case SlashTT:
+		f, _ := new(big.Rat).SetFrac(l, r).Float64()
+		return f
+	case SlashSlashTT:
+		// big.Int.Div is Euclidean division. floor division is derived from truncated one.
+		m := new(big.Int)
+		v.QuoRem(l, r, m)
+		if m.Sign() != 0 && m.Sign() != r.Sign() {
+			v.Sub(v, big.NewInt(1))
+		}
+	case PercentTT:
+		v.Rem(l, r)
+		if v.Sign() != 0 && v.Sign() != r.Sign() {
+			v.Add(v, r)
+		}
+	case StarStarTT:
+		if r.Sign() < 0 {
+			return math.Pow(toFloat64(l), toFloat64(r))
+		}
+		v.Exp(l, r, nil)
+	}
+	return normalizeInt(v)
+}
+
+// negate returns -v
+func negate(v interface{}) interface{} {
+	switch n := v.(type) {
+	case int64:
+		if n != math.MinInt64 {
+			return -n
+		}
+		return new(big.Int).Neg(big.NewInt(n))
+	case *big.Int:
+		return normalizeInt(new(big.Int).Neg(n))
+	case float64:
+		return -n
+	case *Decimal:
+		return n.Neg()
+	}
+	return nil
+}
+
+// bitwise evaluates bitwise operation of integers
+func bitwise(operator *Token, left interface{}, right interface{}) (interface{}, error) {
+	if !isInteger(left) || !isInteger(right) {
+		return nil, RuntimeError.New(operator, "Operands must be integers.")
+	}
+	l, r := toBigInt(left), toBigInt(right)
+
+	v := new(big.Int)
+	switch operator.Type {
+	case AmpersandTT:
+		v.And(l, r)
+	case PipeTT:
+		v.Or(l, r)
+	case CaretTT:
+		v.Xor(l, r)
+	case LessLessTT, GreaterGreaterTT:
+		if r.Sign() < 0 {
+			return nil, RuntimeError.New(operator, "Negative shift count.")
+		}
+		if !r.IsInt64() || r.Int64() > math.MaxInt32 {
+			return nil, RuntimeError.New(operator, "Shift count is too large.")
+		}
+		if operator.Type == LessLessTT {
+			v.Lsh(l, uint(r.Int64()))
+		} else {
+			v.Rsh(l, uint(r.Int64()))
+		}
+	}
+
+	return normalizeInt(v), nil
+}
+
+// formatFloat stringfies float so that it is distinguishable from integer
+func formatFloat(f float64) string {
+	s := fmt.Sprint(f)
+	if strings.ContainsAny(s, ".eIN") {
+		return s
+	}
+	return s + ".0"
+}
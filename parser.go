@@ -5,6 +5,7 @@ type Parser struct {
 	runtime *Runtime
 	tokens  TokenList
 	current int
+	errors  ParseErrors
 }
 
 // NewParser is constructor of Parser
@@ -13,20 +14,32 @@ func NewParser(runtime *Runtime, tokens TokenList) *Parser {
 		runtime: runtime,
 		tokens:  tokens,
 		current: 0,
+		errors:  make(ParseErrors, 0),
 	}
 }
 
-// Parse parses given tokens
+// Parse parses given tokens.
+// It doesn't stop at the first syntax error. It returns statements parsed successfully
+// and ParseErrors which has all errors found.
 func (p *Parser) Parse() ([]Stmt, error) {
 	statements := make([]Stmt, 0)
 	for !p.isAtEnd() {
+		start := p.current
 		stmt, err := p.declaration()
 		if err != nil {
-			return nil, err
+			p.synchronize()
+			if p.current == start {
+				// skip stray dedent
+				p.advance()
+			}
+			continue
 		}
 		statements = append(statements, stmt)
 	}
 
+	if len(p.errors) != 0 {
+		return statements, p.errors
+	}
 	return statements, nil
 }
 
@@ -38,25 +51,21 @@ func (p *Parser) declaration() (Stmt, error) {
 	if p.match(ClassTT) {
 		return p.classDeclaration()
 	}
-	if p.match(FunTT) {
+	// `fun (` begins lambda expression
+	if p.check(FunTT) && !p.checkNext(LeftParenTT) {
+		p.advance()
 		return p.function("function")
 	}
+	if p.match(ImportTT) {
+		return p.importDeclaration()
+	}
 	if p.match(IncludeTT) {
 		return p.include()
 	}
 	if p.match(VarTT) {
-		stmt, err := p.varDecralation()
-		if err != nil {
-			p.synchronize()
-			return nil, err
-		}
-		return stmt, nil
-	}
-	stmt, err := p.statement()
-	if err != nil {
-		p.synchronize()
+		return p.varDecralation()
 	}
-	return stmt, nil
+	return p.statement()
 }
 
 func (p *Parser) classDeclaration() (Stmt, error) {
@@ -79,13 +88,13 @@ func (p *Parser) classDeclaration() (Stmt, error) {
 	if err != nil {
 		return nil, err
 	}
-	_, err = p.consume(LeftBraceTT, "Expect '{' before class body.")
+	_, err = p.consume(IndentTT, "Expect indent before class body.")
 	if err != nil {
 		return nil, err
 	}
 
 	methods := make([]*Function, 0)
-	for !p.check(RightBraceTT) && !p.isAtEnd() {
+	for !p.check(DedentTT) && !p.isAtEnd() {
 		// if p.match(PassTT) {
 		// 	p.consume(NewlineTT, "Expect '\\n' after pass")
 		// 	continue
@@ -93,30 +102,46 @@ func (p *Parser) classDeclaration() (Stmt, error) {
 
 		fun, err := p.function("method")
 		if err != nil {
-			return nil, err
+			p.synchronize()
+			continue
 		}
 		methods = append(methods, fun.(*Function))
 	}
 
-	_, err = p.consume(RightBraceTT, "Expect '}' after class body")
+	_, err = p.consume(DedentTT, "Expect dedent after class body")
 
 	return NewClass(name, superclass, methods), nil
 }
 
 func (p *Parser) statement() (Stmt, error) {
+	if p.match(BreakTT) {
+		return p.breakStatement()
+	}
+	if p.match(ContinueTT) {
+		return p.continueStatement()
+	}
 	if p.match(ForTT) {
 		return p.forStatement()
 	}
 	if p.match(IfTT) {
 		return p.ifStatement()
 	}
+	if p.match(RaiseTT) {
+		return p.raiseStatement()
+	}
 	if p.match(ReturnTT) {
 		return p.returnStatement()
 	}
+	if p.match(TryTT) {
+		return p.tryStatement()
+	}
 	if p.match(WhileTT) {
 		return p.whileStatement()
 	}
-	if p.match(LeftBraceTT) {
+	if p.match(YieldTT) {
+		return p.yieldStatement()
+	}
+	if p.match(IndentTT) {
 		return p.blockStatement(NoneBlock)
 	}
 
@@ -124,7 +149,7 @@ func (p *Parser) statement() (Stmt, error) {
 }
 
 func (p *Parser) blockStatement(typ BlockType) (Stmt, error) {
-	keyword := p.previous() // => '{'
+	keyword := p.previous() // => indent
 	b, err := p.block()
 	if err != nil {
 		return nil, err
@@ -132,7 +157,29 @@ func (p *Parser) blockStatement(typ BlockType) (Stmt, error) {
 	return NewBlock(b, keyword, typ), nil
 }
 
+func (p *Parser) breakStatement() (Stmt, error) {
+	keyword := p.previous()
+	_, err := p.consumeTerm()
+	if err != nil {
+		return nil, err
+	}
+	return NewBreak(keyword), nil
+}
+
+func (p *Parser) continueStatement() (Stmt, error) {
+	keyword := p.previous()
+	_, err := p.consumeTerm()
+	if err != nil {
+		return nil, err
+	}
+	return NewContinue(keyword), nil
+}
+
 func (p *Parser) forStatement() (Stmt, error) {
+	if p.check(IdentifierTT) && p.checkNext(InTT) {
+		return p.forInStatement()
+	}
+
 	var initializer Stmt
 	var err error
 	if p.match(SemicolonTT) {
@@ -177,7 +224,7 @@ func (p *Parser) forStatement() (Stmt, error) {
 		return nil, err
 	}
 
-	keyword, err := p.consume(LeftBraceTT, "Expect '{' for `for` loop body")
+	keyword, err := p.consume(IndentTT, "Expect indent for `for` loop body")
 	if err != nil {
 		return nil, err
 	}
@@ -186,14 +233,11 @@ func (p *Parser) forStatement() (Stmt, error) {
 		return nil, err
 	}
 
-	if increment != nil {
-		body = NewBlock([]Stmt{body, NewExpression(increment)}, keyword, ForBlock)
-	}
-
 	if condition == nil {
 		condition = NewLiteral(true)
 	}
-	body = NewWhile(condition, body)
+	// increment is kept apart from body so that `continue` doesn't skip it.
+	body = NewWhile(condition, body, increment)
 
 	if initializer != nil {
 		body = NewBlock([]Stmt{initializer, body}, keyword, ForBlock)
@@ -202,6 +246,34 @@ func (p *Parser) forStatement() (Stmt, error) {
 	return body, nil
 }
 
+// forInStatement parses `for x in iterable:` loop
+func (p *Parser) forInStatement() (Stmt, error) {
+	name := p.advance()
+	in := p.advance()
+	iterable, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.consume(ColonTT, "Expect ':' after for clauses.")
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.consume(NewlineTT, "Expect '\\n' after for clauses.")
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.consume(IndentTT, "Expect indent for `for` loop body")
+	if err != nil {
+		return nil, err
+	}
+	body, err := p.blockStatement(ForBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewForIn(name, in, iterable, body), nil
+}
+
 func (p *Parser) ifStatement() (Stmt, error) {
 	condition, err := p.expression()
 	if err != nil {
@@ -217,7 +289,7 @@ func (p *Parser) ifStatement() (Stmt, error) {
 		return nil, err
 	}
 
-	_, err = p.consume(LeftBraceTT, "Expect '{' for if then block")
+	_, err = p.consume(IndentTT, "Expect indent for if then block")
 	thenBranch, err := p.blockStatement(IfBlock)
 	if err != nil {
 		return nil, err
@@ -240,7 +312,7 @@ func (p *Parser) ifStatement() (Stmt, error) {
 		if err != nil {
 			return nil, err
 		}
-		_, err = p.consume(LeftBraceTT, "Expect '{' for if else block")
+		_, err = p.consume(IndentTT, "Expect indent for if else block")
 		if err != nil {
 			return nil, err
 		}
@@ -253,11 +325,25 @@ func (p *Parser) ifStatement() (Stmt, error) {
 	return NewIf(condition, thenBranch, elseBranch), nil
 }
 
+func (p *Parser) raiseStatement() (Stmt, error) {
+	keyword := p.previous()
+	value, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = p.consumeTerm()
+	if err != nil {
+		return nil, err
+	}
+	return NewRaise(keyword, value), nil
+}
+
 func (p *Parser) returnStatement() (Stmt, error) {
 	keyword := p.previous()
 	var value Expr = nil
 	var err error
-	if !p.check(SemicolonTT) {
+	if !p.check(SemicolonTT) && !p.check(NewlineTT) {
 		value, err = p.expression()
 		if err != nil {
 			return nil, err
@@ -271,6 +357,118 @@ func (p *Parser) returnStatement() (Stmt, error) {
 	return NewReturn(keyword, value), nil
 }
 
+func (p *Parser) yieldStatement() (Stmt, error) {
+	keyword := p.previous()
+	var value Expr = nil
+	var err error
+	if !p.check(SemicolonTT) && !p.check(NewlineTT) {
+		value, err = p.expression()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = p.consumeTerm()
+	if err != nil {
+		return nil, err
+	}
+	return NewYield(keyword, value), nil
+}
+
+func (p *Parser) tryStatement() (Stmt, error) {
+	keyword := p.previous()
+	_, err := p.consume(ColonTT, "Expect ':' after try.")
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.consume(NewlineTT, "Expect '\\n' after try")
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.consume(IndentTT, "Expect indent for try block")
+	if err != nil {
+		return nil, err
+	}
+	body, err := p.blockStatement(TryBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	handlers := make([]*Except, 0)
+	for p.match(ExceptTT) {
+		handler, err := p.exceptClause()
+		if err != nil {
+			return nil, err
+		}
+		handlers = append(handlers, handler)
+	}
+
+	var finallyBranch Stmt
+	if p.match(FinallyTT) {
+		_, err := p.consume(ColonTT, "Expect ':' after finally.")
+		if err != nil {
+			return nil, err
+		}
+		_, err = p.consume(NewlineTT, "Expect '\\n' after finally")
+		if err != nil {
+			return nil, err
+		}
+		_, err = p.consume(IndentTT, "Expect indent for finally block")
+		if err != nil {
+			return nil, err
+		}
+		finallyBranch, err = p.blockStatement(TryBlock)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(handlers) == 0 && finallyBranch == nil {
+		return nil, p.NewParseError(p.peek(), "Expect 'except' or 'finally' after try block.")
+	}
+
+	return NewTry(keyword, body, handlers, finallyBranch), nil
+}
+
+// exceptClause parses `except [type [as name]]:`
+func (p *Parser) exceptClause() (*Except, error) {
+	keyword := p.previous()
+	var typ Expr
+	var name *Token
+	var err error
+	if !p.check(ColonTT) {
+		typ, err = p.expression()
+		if err != nil {
+			return nil, err
+		}
+		if p.match(AsTT) {
+			name, err = p.consume(IdentifierTT, "Expect variable name after 'as'.")
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	_, err = p.consume(ColonTT, "Expect ':' after except clause.")
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.consume(NewlineTT, "Expect '\\n' after except clause")
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.consume(IndentTT, "Expect indent for except block")
+	if err != nil {
+		return nil, err
+	}
+	body, err := p.blockStatement(TryBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewExcept(keyword, typ, name, body).(*Except), nil
+}
+
 func (p *Parser) whileStatement() (Stmt, error) {
 	condition, err := p.expression()
 	if err != nil {
@@ -284,7 +482,7 @@ func (p *Parser) whileStatement() (Stmt, error) {
 	if err != nil {
 		return nil, err
 	}
-	_, err = p.consume(LeftBraceTT, "Expect '{' for while body")
+	_, err = p.consume(IndentTT, "Expect indent for while body")
 	if err != nil {
 		return nil, err
 	}
@@ -293,7 +491,7 @@ func (p *Parser) whileStatement() (Stmt, error) {
 		return nil, err
 	}
 
-	return NewWhile(condition, body), nil
+	return NewWhile(condition, body, nil), nil
 }
 
 func (p *Parser) varDecralation() (Stmt, error) {
@@ -344,6 +542,8 @@ func (p *Parser) function(kind string) (Stmt, error) {
 		return NewFunction(
 			p.previous(),
 			[]*Token{},
+			nil,
+			nil,
 			[]Stmt{
 				NewExpression(NewLiteral("pass")),
 			},
@@ -359,19 +559,72 @@ func (p *Parser) function(kind string) (Stmt, error) {
 	if err != nil {
 		return nil, err
 	}
+	parameters, defaults, rest, err := p.parameters()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = p.consume(ColonTT, "Expect ':' after '('")
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.consume(NewlineTT, "Expect '\\n' before "+kind+" body.")
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.consume(IndentTT, "Expected an indented block as "+kind+" body.")
+	if err != nil {
+		return nil, err
+	}
+	body, err := p.block()
+	if err != nil {
+		return nil, err
+	}
+	return NewFunction(name, parameters, defaults, rest, body), nil
+}
+
+// parameters parses parameter list and closing parenthesis.
+// defaults has default value of each parameter, nil for required one.
+// defaults itself is nil if no parameter has default value.
+// rest is the parameter prefixed with '*' which collects extra arguments.
+func (p *Parser) parameters() ([]*Token, []Expr, *Token, error) {
 	parameters := make([]*Token, 0)
+	defaults := make([]Expr, 0)
+	var rest *Token
 
 	if !p.check(RightParenTT) {
 		for {
 			if len(parameters) >= 255 {
-				return nil, p.NewParseError(p.peek(), "Can't have more than 255 parameters.")
+				return nil, nil, nil, p.NewParseError(p.peek(), "Can't have more than 255 parameters.")
+			}
+
+			if p.match(StarTT) {
+				token, err := p.consume(IdentifierTT, "Expect parameter name after '*'.")
+				if err != nil {
+					return nil, nil, nil, err
+				}
+				rest = token
+				if p.check(CommaTT) {
+					return nil, nil, nil, p.NewParseError(p.peek(), "Rest parameter must be last.")
+				}
+				break
 			}
 
 			token, err := p.consume(IdentifierTT, "Expect parameter name.")
 			if err != nil {
-				return nil, err
+				return nil, nil, nil, err
+			}
+			var value Expr
+			if p.match(EqualTT) {
+				value, err = p.expression()
+				if err != nil {
+					return nil, nil, nil, err
+				}
+			} else if len(defaults) != 0 && defaults[len(defaults)-1] != nil {
+				return nil, nil, nil, p.NewParseError(token, "Non-default parameter follows default parameter.")
 			}
 			parameters = append(parameters, token)
+			defaults = append(defaults, value)
 
 			if !p.match(CommaTT) {
 				break
@@ -379,28 +632,64 @@ func (p *Parser) function(kind string) (Stmt, error) {
 		}
 	}
 
-	_, err = p.consume(RightParenTT, "Expect ')' after parameters.")
+	_, err := p.consume(RightParenTT, "Expect ')' after parameters.")
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 
-	_, err = p.consume(ColonTT, "Expect ':' after '('")
+	if len(defaults) == 0 || defaults[len(defaults)-1] == nil {
+		// defaults are trailing, so the last one is nil only if no default value is given
+		defaults = nil
+	}
+
+	return parameters, defaults, rest, nil
+}
+
+// lambda parses anonymous function.
+// Its body is a single expression or an indented block.
+//
+//	fun (x, y): x + y
+//	fun (x):
+//	    return x
+func (p *Parser) lambda() (Expr, error) {
+	keyword := p.previous()
+	_, err := p.consume(LeftParenTT, "Expect '(' after 'fun'.")
 	if err != nil {
 		return nil, err
 	}
-	_, err = p.consume(NewlineTT, "Expect '\\n' before "+kind+" body.")
+	parameters, defaults, rest, err := p.parameters()
 	if err != nil {
 		return nil, err
 	}
-	_, err = p.consume(LeftBraceTT, "Expected an indented block as "+kind+" body.")
+	_, err = p.consume(ColonTT, "Expect ':' after ')'")
 	if err != nil {
 		return nil, err
 	}
-	body, err := p.block()
+
+	name := NewToken(IdentifierTT, "<lambda>", nil, keyword.Line)
+	name.Column = keyword.Column
+	name.Offset = keyword.Offset
+	name.File = keyword.File
+
+	if p.match(NewlineTT) {
+		_, err = p.consume(IndentTT, "Expected an indented block as lambda body.")
+		if err != nil {
+			return nil, err
+		}
+		body, err := p.block()
+		if err != nil {
+			return nil, err
+		}
+		return NewLambda(NewFunction(name, parameters, defaults, rest, body).(*Function)), nil
+	}
+
+	value, err := p.expression()
 	if err != nil {
 		return nil, err
 	}
-	return NewFunction(name, parameters, body), nil
+	body := []Stmt{NewReturn(NewToken(ReturnTT, "return", nil, keyword.Line), value)}
+
+	return NewLambda(NewFunction(name, parameters, defaults, rest, body).(*Function)), nil
 }
 
 func (p *Parser) include() (Stmt, error) {
@@ -415,22 +704,46 @@ func (p *Parser) include() (Stmt, error) {
 	return NewInclude(s), nil
 }
 
+// importDeclaration parses `import "path" as name`
+func (p *Parser) importDeclaration() (Stmt, error) {
+	keyword := p.previous()
+	path, err := p.consume(StringTT, "Expect string after import.")
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.consume(AsTT, "Expect 'as' after module path.")
+	if err != nil {
+		return nil, err
+	}
+	name, err := p.consume(IdentifierTT, "Expect module name after 'as'.")
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.consumeTerm()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewImport(keyword, path, name), nil
+}
+
 func (p *Parser) block() ([]Stmt, error) {
 	statements := make([]Stmt, 0)
-	for !p.check(RightBraceTT) && !p.isAtEnd() {
+	for !p.check(DedentTT) && !p.isAtEnd() {
 		stmt, err := p.declaration()
 		if err != nil {
-			return nil, err
+			p.synchronize()
+			continue
 		}
 		statements = append(statements, stmt)
 	}
 
-	p.consume(RightBraceTT, "Expect '}' after block.")
+	p.consume(DedentTT, "Expect dedent after block.")
 	return statements, nil
 }
 
 func (p *Parser) assignment() (Expr, error) {
-	expr, err := p.or()
+	expr, err := p.conditional()
 	if err != nil {
 		return nil, err
 	}
@@ -447,9 +760,38 @@ func (p *Parser) assignment() (Expr, error) {
 		} else if expr.IsType(&Get{}) {
 			get := expr.(*Get)
 			return NewSet(get.Object, get.Name, value), nil
+		} else if expr.IsType(&Index{}) {
+			index := expr.(*Index)
+			return NewSetIndex(index.Object, index.Bracket, index.Index, value), nil
 		}
 
-		p.runtime.ErrorTokenMessage(equals, "Invalid assignment target.")
+		p.NewParseError(equals, "Invalid assignment target.")
+	}
+
+	return expr, nil
+}
+
+// conditional parses `then if condition else otherwise`
+func (p *Parser) conditional() (Expr, error) {
+	expr, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.match(IfTT) {
+		condition, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		_, err = p.consume(ElseTT, "Expect 'else' after condition.")
+		if err != nil {
+			return nil, err
+		}
+		elseBranch, err := p.conditional()
+		if err != nil {
+			return nil, err
+		}
+		expr = NewConditional(condition, expr, elseBranch)
 	}
 
 	return expr, nil
@@ -474,14 +816,14 @@ func (p *Parser) or() (Expr, error) {
 }
 
 func (p *Parser) and() (Expr, error) {
-	expr, err := p.equality()
+	expr, err := p.comparison()
 	if err != nil {
 		return nil, err
 	}
 
 	for p.match(AndTT) {
 		operator := p.previous()
-		right, err := p.equality()
+		right, err := p.comparison()
 		if err != nil {
 			return nil, err
 		}
@@ -491,15 +833,43 @@ func (p *Parser) and() (Expr, error) {
 	return expr, nil
 }
 
-func (p *Parser) equality() (Expr, error) {
-	expr, err := p.comparison()
+func (p *Parser) comparison() (Expr, error) {
+	expr, err := p.bitOr()
+	if err != nil {
+		return nil, err
+	}
+
+	// a < b < c is chained comparison, which means a < b and b < c.
+	// Equality operators have the same precedence and are chained as well.
+	operands := []Expr{expr}
+	operators := []*Token{}
+	for p.match(GreaterTT, GreaterEqualTT, LessTT, LessEqualTT, EqualEqualTT, BangEqualTT) {
+		operators = append(operators, p.previous())
+		right, err := p.bitOr()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, right)
+	}
+
+	switch len(operators) {
+	case 0:
+		return expr, nil
+	case 1:
+		return NewBinary(operands[0], operators[0], operands[1]), nil
+	}
+	return NewComparison(operands, operators), nil
+}
+
+func (p *Parser) bitOr() (Expr, error) {
+	expr, err := p.bitXor()
 	if err != nil {
 		return nil, err
 	}
 
-	for p.match(BangEqualTT, EqualEqualTT) {
+	for p.match(PipeTT) {
 		operator := p.previous()
-		right, err := p.comparison()
+		right, err := p.bitXor()
 		if err != nil {
 			return nil, err
 		}
@@ -509,19 +879,54 @@ func (p *Parser) equality() (Expr, error) {
 	return expr, nil
 }
 
-func (p *Parser) comparison() (Expr, error) {
+func (p *Parser) bitXor() (Expr, error) {
+	expr, err := p.bitAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.match(CaretTT) {
+		operator := p.previous()
+		right, err := p.bitAnd()
+		if err != nil {
+			return nil, err
+		}
+		expr = NewBinary(expr, operator, right)
+	}
+
+	return expr, nil
+}
+
+func (p *Parser) bitAnd() (Expr, error) {
+	expr, err := p.shift()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.match(AmpersandTT) {
+		operator := p.previous()
+		right, err := p.shift()
+		if err != nil {
+			return nil, err
+		}
+		expr = NewBinary(expr, operator, right)
+	}
+
+	return expr, nil
+}
+
+func (p *Parser) shift() (Expr, error) {
 	expr, err := p.term()
 	if err != nil {
 		return nil, err
 	}
 
-	for p.match(GreaterTT, GreaterEqualTT, LessTT, LessEqualTT) {
+	for p.match(LessLessTT, GreaterGreaterTT) {
 		operator := p.previous()
 		right, err := p.term()
 		if err != nil {
 			return nil, err
 		}
-
 		expr = NewBinary(expr, operator, right)
 	}
 
@@ -552,7 +957,7 @@ func (p *Parser) factor() (Expr, error) {
 		return nil, err
 	}
 
-	for p.match(SlashTT, StarTT) {
+	for p.match(SlashTT, StarTT, SlashSlashTT, PercentTT) {
 		operator := p.previous()
 		right, err := p.unary()
 		if err != nil {
@@ -566,7 +971,7 @@ func (p *Parser) factor() (Expr, error) {
 }
 
 func (p *Parser) unary() (Expr, error) {
-	if p.match(BangTT, MinusTT) {
+	if p.match(BangTT, MinusTT, TildeTT) {
 		operator := p.previous()
 		right, err := p.unary()
 		if err != nil {
@@ -575,7 +980,27 @@ func (p *Parser) unary() (Expr, error) {
 		return NewUnary(operator, right), nil
 	}
 
-	return p.call()
+	return p.power()
+}
+
+// power parses exponentiation. It is right associative and binds tighter than unary operator on its left.
+// e.g., -2 ** 2 is -(2 ** 2) and 2 ** -1 is 2 ** (-1)
+func (p *Parser) power() (Expr, error) {
+	expr, err := p.call()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.match(StarStarTT) {
+		operator := p.previous()
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		expr = NewBinary(expr, operator, right)
+	}
+
+	return expr, nil
 }
 
 func (p *Parser) call() (Expr, error) {
@@ -596,6 +1021,11 @@ func (p *Parser) call() (Expr, error) {
 				return nil, err
 			}
 			expr = NewGet(expr, name)
+		} else if p.match(LeftBracketTT) {
+			expr, err = p.finishIndex(expr)
+			if err != nil {
+				return nil, err
+			}
 		} else {
 			break
 		}
@@ -604,15 +1034,58 @@ func (p *Parser) call() (Expr, error) {
 	return expr, nil
 }
 
+// finishIndex parses index such as xs[0] or slice such as xs[1:3].
+// Both ends of slice are optional.
+func (p *Parser) finishIndex(object Expr) (Expr, error) {
+	bracket := p.previous()
+	var start, stop Expr
+	var err error
+	if !p.check(ColonTT) {
+		start, err = p.expression()
+		if err != nil {
+			return nil, err
+		}
+		if !p.check(ColonTT) {
+			_, err = p.consume(RightBracketTT, "Expect ']' after index.")
+			if err != nil {
+				return nil, err
+			}
+			return NewIndex(object, bracket, start), nil
+		}
+	}
+
+	p.advance() // consume ':'
+	if !p.check(RightBracketTT) {
+		stop, err = p.expression()
+		if err != nil {
+			return nil, err
+		}
+	}
+	_, err = p.consume(RightBracketTT, "Expect ']' after slice.")
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSlice(object, bracket, start, stop), nil
+}
+
 func (p *Parser) finishCall(callee Expr) (Expr, error) {
 	arguments := make([]Expr, 0)
+	hasKeyword := false
 	if !p.check(RightParenTT) {
 		for {
-			expr, err := p.expression()
+			start := p.peek()
+			expr, err := p.argument()
 			if err != nil {
 				return nil, err
 			}
 
+			if _, ok := expr.(*KeywordArg); ok {
+				hasKeyword = true
+			} else if hasKeyword {
+				return nil, p.NewParseError(start, "Positional argument follows keyword argument.")
+			}
+
 			if len(arguments) >= 255 {
 				return nil, p.NewParseError(p.peek(), "Can't have more than 255 arguments.")
 			}
@@ -633,6 +1106,115 @@ func (p *Parser) finishCall(callee Expr) (Expr, error) {
 	return NewCall(callee, paren, arguments), nil
 }
 
+// argument parses an argument of function call.
+// `*xs` spreads elements of list xs as arguments, and `name = value` is keyword argument.
+func (p *Parser) argument() (Expr, error) {
+	if p.check(IdentifierTT) && p.checkNext(EqualTT) {
+		name := p.advance()
+		p.advance() // =
+		value, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		return NewKeywordArg(name, value), nil
+	}
+	if p.match(StarTT) {
+		star := p.previous()
+		value, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		return NewSpread(star, value), nil
+	}
+
+	return p.expression()
+}
+
+func (p *Parser) list() (Expr, error) {
+	bracket := p.previous()
+	elements := make([]Expr, 0)
+	for !p.check(RightBracketTT) {
+		expr, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, expr)
+
+		if !p.match(CommaTT) {
+			break
+		}
+	}
+
+	_, err := p.consume(RightBracketTT, "Expect ']' after list elements.")
+	if err != nil {
+		return nil, err
+	}
+
+	return NewList(bracket, elements), nil
+}
+
+func (p *Parser) dictionary() (Expr, error) {
+	brace := p.previous()
+	keys := make([]Expr, 0)
+	values := make([]Expr, 0)
+	for !p.check(RightBraceTT) {
+		key, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		_, err = p.consume(ColonTT, "Expect ':' after dictionary key.")
+		if err != nil {
+			return nil, err
+		}
+		value, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+		values = append(values, value)
+
+		if !p.match(CommaTT) {
+			break
+		}
+	}
+
+	_, err := p.consume(RightBraceTT, "Expect '}' after dictionary entries.")
+	if err != nil {
+		return nil, err
+	}
+
+	return NewMap(brace, keys, values), nil
+}
+
+// fstring parses f-string. Text parts become Literal.
+func (p *Parser) fstring() (Expr, error) {
+	fstring := p.previous()
+	parts := make([]Expr, 0)
+	for p.match(StringTT, InterpolationStartTT) {
+		if p.previous().Type == StringTT {
+			parts = append(parts, NewLiteral(p.previous().Literal))
+			continue
+		}
+
+		expr, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, expr)
+		_, err = p.consume(InterpolationEndTT, "Expect '}' after expression in f-string.")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_, err := p.consume(FStringEndTT, "Expect '\"' after f-string.")
+	if err != nil {
+		return nil, err
+	}
+
+	return NewInterpolation(fstring, parts), nil
+}
+
 func (p *Parser) primary() (Expr, error) {
 	if p.match(FalseTT) {
 		return NewLiteral(false), nil
@@ -649,6 +1231,9 @@ func (p *Parser) primary() (Expr, error) {
 	if p.match(NumberTT, StringTT) {
 		return NewLiteral(p.previous().Literal), nil
 	}
+	if p.match(FStringStartTT) {
+		return p.fstring()
+	}
 	if p.match(SuperTT) {
 		keyword := p.previous()
 		_, err := p.consume(DotTT, "Expect '.' after 'super'.")
@@ -680,10 +1265,15 @@ func (p *Parser) primary() (Expr, error) {
 
 		return NewGrouping(expr), nil
 	}
-	if p.match(NewlineTT) {
-		return NewLiteral('\n'), nil
+	if p.match(LeftBracketTT) {
+		return p.list()
+	}
+	if p.match(LeftBraceTT) {
+		return p.dictionary()
+	}
+	if p.match(FunTT) {
+		return p.lambda()
 	}
-
 	return nil, p.NewParseError(p.peek(), "Expect expression.")
 }
 
@@ -707,6 +1297,10 @@ func (p *Parser) consume(typ TokenType, message string) (*Token, error) {
 }
 
 func (p *Parser) consumeTerm() (*Token, error) {
+	// the line break before the dedent of lambda body terminates the statement
+	if p.previous().Type == DedentTT {
+		return p.previous(), nil
+	}
 	if p.check(NewlineTT) {
 		return p.advance(), nil
 	}
@@ -728,6 +1322,13 @@ func (p *Parser) check(typ TokenType) bool {
 	return p.peek().Type == typ
 }
 
+func (p *Parser) checkNext(typ TokenType) bool {
+	if p.isAtEnd() || p.tokens[p.current+1].Type == EOFTT {
+		return false
+	}
+	return p.tokens[p.current+1].Type == typ
+}
+
 func (p *Parser) advance() *Token {
 	if !p.isAtEnd() {
 		p.current++
@@ -747,43 +1348,39 @@ func (p *Parser) previous() *Token {
 	return p.tokens[p.current-1]
 }
 
+// synchronize discards tokens until the beginning of next statement.
+// The indented block following the erroneous line is parsed as a block to find errors in it,
+// and a dedent closing the current block is left for the enclosing block.
 func (p *Parser) synchronize() {
-	p.advance()
-
+	advanced := false
 	for !p.isAtEnd() {
-		switch p.previous().Type {
-		case SemicolonTT:
-			return
-		case NewlineTT:
-			return
-		}
-		// if p.previous().Type == Semicolon {
-		// 	return
-		// }
-
 		switch p.peek().Type {
-		case ClassTT:
-			return
-		case FunTT:
-			return
-		case VarTT:
+		case DedentTT:
 			return
-		case ForTT:
-			return
-		case IfTT:
-			return
-		case WhileTT:
-			return
-		case ReturnTT:
+		case NewlineTT, SemicolonTT:
+			if p.advance().Type == SemicolonTT {
+				p.match(NewlineTT)
+			}
+			if p.match(IndentTT) {
+				p.block()
+			}
 			return
+		case ClassTT, FunTT, VarTT, ForTT, IfTT, WhileTT, ReturnTT, YieldTT:
+			if advanced {
+				return
+			}
 		}
 
 		p.advance()
+		advanced = true
 	}
 }
 
 // NewParseError is constructor of ParseError
+// It reports the error and records it.
 func (p *Parser) NewParseError(token *Token, message string) error {
 	p.runtime.ErrorTokenMessage(token, message)
-	return ParseError.New(token, message)
+	err := ParseError.New(token, message)
+	p.errors = append(p.errors, err)
+	return err
 }
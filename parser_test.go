@@ -1,6 +1,7 @@
 package tlps_test
 
 import (
+	"bytes"
 	"fmt"
 	"testing"
 
@@ -60,7 +61,7 @@ func TestParser(t *testing.T) {
 								),
 							),
 						},
-						tlps.NewToken(tlps.LeftBraceTT, "{", nil, 2),
+						tlps.NewToken(tlps.IndentTT, "<indent>", nil, 2),
 						tlps.IfBlock,
 					),
 					nil,
@@ -71,13 +72,13 @@ func TestParser(t *testing.T) {
 				tlps.NewToken(tlps.TrueTT, "true", nil, 1),
 				tlps.NewToken(tlps.ColonTT, ":", nil, 1),
 				tlps.NewToken(tlps.NewlineTT, "\n", nil, 1),
-				tlps.NewToken(tlps.LeftBraceTT, "{", nil, 2),
+				tlps.NewToken(tlps.IndentTT, "<indent>", nil, 2),
 				tlps.NewToken(tlps.IdentifierTT, "print", nil, 2),
 				tlps.NewToken(tlps.LeftParenTT, "(", nil, 2),
 				tlps.NewToken(tlps.NumberTT, "1", 1.0, 2),
 				tlps.NewToken(tlps.RightParenTT, ")", nil, 2),
 				tlps.NewToken(tlps.NewlineTT, "\n", nil, 1),
-				tlps.NewToken(tlps.RightBraceTT, "}", nil, 2),
+				tlps.NewToken(tlps.DedentTT, "<dedent>", nil, 2),
 				tlps.NewToken(tlps.EOFTT, "", nil, 1),
 			},
 		},
@@ -100,7 +101,7 @@ func TestParser(t *testing.T) {
 								),
 							),
 						},
-						tlps.NewToken(tlps.LeftBraceTT, "{", nil, 2),
+						tlps.NewToken(tlps.IndentTT, "<indent>", nil, 2),
 						tlps.IfBlock,
 					),
 					tlps.NewBlock(
@@ -117,7 +118,7 @@ func TestParser(t *testing.T) {
 								),
 							),
 						},
-						tlps.NewToken(tlps.LeftBraceTT, "{", nil, 4),
+						tlps.NewToken(tlps.IndentTT, "<indent>", nil, 4),
 						tlps.IfBlock,
 					),
 				),
@@ -128,25 +129,25 @@ func TestParser(t *testing.T) {
 				tlps.NewToken(tlps.TrueTT, "true", nil, 1),
 				tlps.NewToken(tlps.ColonTT, ":", nil, 1),
 				tlps.NewToken(tlps.NewlineTT, "\n", nil, 1),
-				tlps.NewToken(tlps.LeftBraceTT, "{", nil, 2),
+				tlps.NewToken(tlps.IndentTT, "<indent>", nil, 2),
 				tlps.NewToken(tlps.IdentifierTT, "print", nil, 2),
 				tlps.NewToken(tlps.LeftParenTT, "(", nil, 2),
 				tlps.NewToken(tlps.NumberTT, "1", 1.0, 2),
 				tlps.NewToken(tlps.RightParenTT, ")", nil, 2),
 				tlps.NewToken(tlps.NewlineTT, "\n", nil, 1),
-				tlps.NewToken(tlps.RightBraceTT, "}", nil, 2),
+				tlps.NewToken(tlps.DedentTT, "<dedent>", nil, 2),
 
 				// else branch
 				tlps.NewToken(tlps.ElseTT, "else", nil, 2),
 				tlps.NewToken(tlps.ColonTT, ":", nil, 2),
 				tlps.NewToken(tlps.NewlineTT, "\n", nil, 2),
-				tlps.NewToken(tlps.LeftBraceTT, "{", nil, 4),
+				tlps.NewToken(tlps.IndentTT, "<indent>", nil, 4),
 				tlps.NewToken(tlps.IdentifierTT, "print", nil, 4),
 				tlps.NewToken(tlps.LeftParenTT, "(", nil, 4),
 				tlps.NewToken(tlps.NumberTT, "2", 2.0, 4),
 				tlps.NewToken(tlps.RightParenTT, ")", nil, 4),
 				tlps.NewToken(tlps.NewlineTT, "\n", nil, 4),
-				tlps.NewToken(tlps.RightBraceTT, "}", nil, 4),
+				tlps.NewToken(tlps.DedentTT, "<dedent>", nil, 4),
 				tlps.NewToken(tlps.EOFTT, "", nil, 4),
 			},
 		},
@@ -167,44 +168,36 @@ func TestParser(t *testing.T) {
 							),
 							tlps.NewBlock(
 								[]tlps.Stmt{
-									tlps.NewBlock(
-										[]tlps.Stmt{
-											tlps.NewExpression(
-												tlps.NewCall(
-													tlps.NewVariable(
-														tlps.NewToken(tlps.IdentifierTT, "print", nil, 2),
-													),
-													tlps.NewToken(tlps.RightParenTT, ")", nil, 2),
-													[]tlps.Expr{
-														tlps.NewVariable(
-															tlps.NewToken(tlps.IdentifierTT, "i", nil, 2),
-														),
-													},
-												),
-											),
-										},
-										tlps.NewToken(tlps.LeftBraceTT, "{", nil, 2),
-										tlps.ForBlock,
-									),
 									tlps.NewExpression(
-										tlps.NewAssign(
-											tlps.NewToken(tlps.IdentifierTT, "i", nil, 1),
-											tlps.NewBinary(
+										tlps.NewCall(
+											tlps.NewVariable(
+												tlps.NewToken(tlps.IdentifierTT, "print", nil, 2),
+											),
+											tlps.NewToken(tlps.RightParenTT, ")", nil, 2),
+											[]tlps.Expr{
 												tlps.NewVariable(
-													tlps.NewToken(tlps.IdentifierTT, "i", nil, 1),
+													tlps.NewToken(tlps.IdentifierTT, "i", nil, 2),
 												),
-												tlps.NewToken(tlps.PlusTT, "+", nil, 1),
-												tlps.NewLiteral(1.0),
-											),
+											},
 										),
 									),
 								},
-								tlps.NewToken(tlps.LeftBraceTT, "{", nil, 2),
+								tlps.NewToken(tlps.IndentTT, "<indent>", nil, 2),
 								tlps.ForBlock,
 							),
+							tlps.NewAssign(
+								tlps.NewToken(tlps.IdentifierTT, "i", nil, 1),
+								tlps.NewBinary(
+									tlps.NewVariable(
+										tlps.NewToken(tlps.IdentifierTT, "i", nil, 1),
+									),
+									tlps.NewToken(tlps.PlusTT, "+", nil, 1),
+									tlps.NewLiteral(1.0),
+								),
+							),
 						),
 					},
-					tlps.NewToken(tlps.LeftBraceTT, "{", nil, 2),
+					tlps.NewToken(tlps.IndentTT, "<indent>", nil, 2),
 					tlps.ForBlock,
 				),
 			},
@@ -226,13 +219,13 @@ func TestParser(t *testing.T) {
 				tlps.NewToken(tlps.NumberTT, "1", 1.0, 1),
 				tlps.NewToken(tlps.ColonTT, ":", nil, 1),
 				tlps.NewToken(tlps.NewlineTT, "\n", nil, 1),
-				tlps.NewToken(tlps.LeftBraceTT, "{", nil, 2),
+				tlps.NewToken(tlps.IndentTT, "<indent>", nil, 2),
 				tlps.NewToken(tlps.IdentifierTT, "print", nil, 2),
 				tlps.NewToken(tlps.LeftParenTT, "(", nil, 2),
 				tlps.NewToken(tlps.IdentifierTT, "i", nil, 2),
 				tlps.NewToken(tlps.RightParenTT, ")", nil, 2),
 				tlps.NewToken(tlps.NewlineTT, "\n", nil, 2),
-				tlps.NewToken(tlps.RightBraceTT, "}", nil, 2),
+				tlps.NewToken(tlps.DedentTT, "<dedent>", nil, 2),
 				tlps.NewToken(tlps.EOFTT, "", nil, 2),
 			},
 		},
@@ -247,6 +240,8 @@ func TestParser(t *testing.T) {
 						tlps.NewToken(tlps.IdentifierTT, "x", nil, 1),
 						tlps.NewToken(tlps.IdentifierTT, "y", nil, 1),
 					},
+					nil,
+					nil,
 					[]tlps.Stmt{
 						tlps.NewReturn(
 							tlps.NewToken(tlps.ReturnTT, "return", nil, 2),
@@ -269,13 +264,13 @@ func TestParser(t *testing.T) {
 				tlps.NewToken(tlps.RightParenTT, ")", nil, 1),
 				tlps.NewToken(tlps.ColonTT, ":", nil, 1),
 				tlps.NewToken(tlps.NewlineTT, "\\n", nil, 1),
-				tlps.NewToken(tlps.LeftBraceTT, "{", nil, 2),
+				tlps.NewToken(tlps.IndentTT, "<indent>", nil, 2),
 				tlps.NewToken(tlps.ReturnTT, "return", nil, 2),
 				tlps.NewToken(tlps.IdentifierTT, "x", nil, 2),
 				tlps.NewToken(tlps.PlusTT, "+", nil, 2),
 				tlps.NewToken(tlps.IdentifierTT, "y", nil, 2),
 				tlps.NewToken(tlps.NewlineTT, "\\n", nil, 2),
-				tlps.NewToken(tlps.RightBraceTT, "}", nil, 2),
+				tlps.NewToken(tlps.DedentTT, "<dedent>", nil, 2),
 				tlps.NewToken(tlps.EOFTT, "", nil, 2),
 			},
 		},
@@ -294,6 +289,8 @@ func TestParser(t *testing.T) {
 							[]*tlps.Token{
 								tlps.NewToken(tlps.IdentifierTT, "x", nil, 2),
 							},
+							nil,
+							nil,
 							[]tlps.Stmt{
 								tlps.NewExpression(
 									tlps.NewSet(
@@ -316,22 +313,22 @@ func TestParser(t *testing.T) {
 				tlps.NewToken(tlps.IdentifierTT, "Hoge", nil, 1),
 				tlps.NewToken(tlps.ColonTT, ":", nil, 1),
 				tlps.NewToken(tlps.NewlineTT, "\n", nil, 1),
-				tlps.NewToken(tlps.LeftBraceTT, "{", nil, 2),
+				tlps.NewToken(tlps.IndentTT, "<indent>", nil, 2),
 				tlps.NewToken(tlps.IdentifierTT, "init", nil, 2),
 				tlps.NewToken(tlps.LeftParenTT, "(", nil, 2),
 				tlps.NewToken(tlps.IdentifierTT, "x", nil, 2),
 				tlps.NewToken(tlps.RightParenTT, ")", nil, 2),
 				tlps.NewToken(tlps.ColonTT, ":", nil, 2),
 				tlps.NewToken(tlps.NewlineTT, "\n", nil, 2),
-				tlps.NewToken(tlps.LeftBraceTT, "{", nil, 3),
+				tlps.NewToken(tlps.IndentTT, "<indent>", nil, 3),
 				tlps.NewToken(tlps.ThisTT, "this", nil, 3),
 				tlps.NewToken(tlps.DotTT, ".", nil, 3),
 				tlps.NewToken(tlps.IdentifierTT, "x", nil, 3),
 				tlps.NewToken(tlps.EqualTT, "=", nil, 3),
 				tlps.NewToken(tlps.IdentifierTT, "x", nil, 3),
 				tlps.NewToken(tlps.NewlineTT, "\n", nil, 3),
-				tlps.NewToken(tlps.RightBraceTT, "}", nil, 3),
-				tlps.NewToken(tlps.RightBraceTT, "}", nil, 3),
+				tlps.NewToken(tlps.DedentTT, "<dedent>", nil, 3),
+				tlps.NewToken(tlps.DedentTT, "<dedent>", nil, 3),
 				tlps.NewToken(tlps.EOFTT, "", nil, 3),
 			},
 		},
@@ -349,3 +346,103 @@ func TestParser(t *testing.T) {
 		})
 	}
 }
+
+func TestParser_Errors(t *testing.T) {
+	var tests = []struct {
+		name     string
+		expected []string
+		numStmts int
+		code     string
+	}{
+		{
+			name: "errors in top level",
+			expected: []string{
+				"ParseError: Expect expression.",
+				"ParseError: Expect ';' or '\\n' after expression",
+			},
+			numStmts: 1,
+			code:     "var x = 1 +\nprint(x)\nprint(x) x\n",
+		},
+		{
+			name: "errors in body",
+			expected: []string{
+				"ParseError: Expect ')' after parameters.",
+				"ParseError: Expect variable name.",
+				"ParseError: Invalid assignment target.",
+			},
+			numStmts: 1,
+			code: `fun f(x y):
+    var = 1
+    if x:
+        1 = 2
+print(1)
+`,
+		},
+		{
+			name: "rest parameter",
+			expected: []string{
+				"ParseError: Rest parameter must be last.",
+				"ParseError: Expect parameter name after '*'.",
+			},
+			numStmts: 1,
+			code: `fun f(*xs, y):
+    return xs
+fun g(x, *):
+    return x
+fun h(x, *xs):
+    return xs
+`,
+		},
+		{
+			name: "default and keyword argument",
+			expected: []string{
+				"ParseError: Non-default parameter follows default parameter.",
+				"ParseError: Positional argument follows keyword argument.",
+			},
+			numStmts: 1,
+			code: `fun f(x = 1, y):
+    return x
+f(x = 1, 2)
+f(1, y = 2)
+`,
+		},
+		{
+			name: "error in method",
+			expected: []string{
+				"ParseError: Expect parameter name.",
+			},
+			numStmts: 2,
+			code: `class Hoge:
+    init(:
+        this.x = 1
+    bar():
+        return 1
+Hoge()
+`,
+		},
+		{
+			name: "conditional expression without else",
+			expected: []string{
+				"ParseError: Expect 'else' after condition.",
+			},
+			numStmts: 1,
+			code:     "var x = 1 if true\nprint(x)\n",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			r := tlps.NewRuntime()
+			tokens := tlps.NewScanner(r, bytes.NewBufferString(tt.code)).ScanTokens()
+			stmts, err := tlps.NewParser(r, tokens).Parse()
+			assert.Len(t, stmts, tt.numStmts)
+
+			actual := make([]string, 0)
+			for _, e := range err.(tlps.ParseErrors) {
+				actual = append(actual, e.Error())
+			}
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package tlps
+
+import "bytes"
+
+// prelude is TLPS source code which is loaded before any script
+const prelude = `class Exception:
+    init(message = ""):
+        this.message = message
+
+class RuntimeError(Exception):
+    pass
+
+class StopIteration(Exception):
+    pass
+`
+
+// loadPrelude defines builtin classes written in TLPS
+func (i *Interpreter) loadPrelude() {
+	r := i.Runtime
+	previousFile := r.File
+	previousEnvironment := r.Environment
+	r.File = "<prelude>"
+	r.Environment = r.Builtins
+	defer func() {
+		r.File = previousFile
+		r.Environment = previousEnvironment
+	}()
+
+	tokens := NewScanner(r, bytes.NewBufferString(prelude)).ScanTokens()
+	statements, _ := NewParser(r, tokens).Parse()
+	NewResolver(r, i).ResolveStmts(statements)
+	i.Interpret(statements)
+
+	r.ExceptionClass = r.Builtins.Values["Exception"].(*TLPSClass)
+	r.RuntimeErrorClass = r.Builtins.Values["RuntimeError"].(*TLPSClass)
+	r.StopIterationClass = r.Builtins.Values["StopIteration"].(*TLPSClass)
+}
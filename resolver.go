@@ -9,7 +9,9 @@ type Resolver struct {
 	runtime         *Runtime
 	Interpreter     *Interpreter
 	currentFunction FunctionType
+	currentDecl     *Function // declaration of the function being resolved
 	currentClass    ClassType
+	currentLoop     BlockType
 }
 
 // FunctionType is current scope function type
@@ -37,6 +39,7 @@ func NewResolver(runtime *Runtime, interpreter *Interpreter) *Resolver {
 		Interpreter:     interpreter,
 		currentFunction: NoneFT,
 		currentClass:    NoneCT,
+		currentLoop:     NoneBlock,
 	}
 }
 
@@ -44,9 +47,22 @@ func (r *Resolver) visitBlockStmt(stmt *Block) (interface{}, error) {
 	if stmt.Typ == NoneBlock {
 		r.runtime.ErrorTokenMessage(stmt.Keyword, "unexpected indent")
 	}
+
+	enclosingLoop := r.currentLoop
+	if stmt.Typ == ForBlock || stmt.Typ == WhileBlock {
+		r.currentLoop = stmt.Typ
+	}
 	r.beginScope()
 	r.ResolveStmts(stmt.Statements)
 	r.endScope()
+	r.currentLoop = enclosingLoop
+	return nil, nil
+}
+
+func (r *Resolver) visitBreakStmt(stmt *Break) (interface{}, error) {
+	if r.currentLoop == NoneBlock {
+		r.runtime.ErrorTokenMessage(stmt.Keyword, "Can't use 'break' outside of a loop.")
+	}
 	return nil, nil
 }
 
@@ -100,10 +116,59 @@ func (r *Resolver) visitClassStmt(stmt *Class) (interface{}, error) {
 	return nil, nil
 }
 
+func (r *Resolver) visitContinueStmt(stmt *Continue) (interface{}, error) {
+	if r.currentLoop == NoneBlock {
+		r.runtime.ErrorTokenMessage(stmt.Keyword, "Can't use 'continue' outside of a loop.")
+	}
+	return nil, nil
+}
+
+func (r *Resolver) visitExceptStmt(stmt *Except) (interface{}, error) {
+	if stmt.Typ != nil {
+		_, err := r.resolveExpr(stmt.Typ)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// exception is bound in the scope enclosing except block
+	r.beginScope()
+	if stmt.Name != nil {
+		r.declare(stmt.Name)
+		r.define(stmt.Name)
+	}
+	_, err := r.resolveStmt(stmt.Body)
+	r.endScope()
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
 func (r *Resolver) visitExpressionStmt(stmt *Expression) (interface{}, error) {
 	return r.resolveExpr(stmt.Expression)
 }
 
+func (r *Resolver) visitForInStmt(stmt *ForIn) (interface{}, error) {
+	_, err := r.resolveExpr(stmt.Iterable)
+	if err != nil {
+		return nil, err
+	}
+
+	// loop variable is bound in the scope enclosing loop body
+	r.beginScope()
+	r.declare(stmt.Name)
+	r.define(stmt.Name)
+	_, err = r.resolveStmt(stmt.Body)
+	r.endScope()
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
 func (r *Resolver) visitFunctionStmt(stmt *Function) (interface{}, error) {
 	r.declare(stmt.Name)
 	r.define(stmt.Name)
@@ -131,10 +196,20 @@ func (r *Resolver) visitIfStmt(stmt *If) (interface{}, error) {
 	return nil, nil
 }
 
+func (r *Resolver) visitImportStmt(stmt *Import) (interface{}, error) {
+	r.declare(stmt.Name)
+	r.define(stmt.Name)
+	return nil, nil
+}
+
 func (r *Resolver) visitIncludeStmt(stmt *Include) (interface{}, error) {
 	return nil, nil
 }
 
+func (r *Resolver) visitRaiseStmt(stmt *Raise) (interface{}, error) {
+	return r.resolveExpr(stmt.Value)
+}
+
 func (r *Resolver) visitReturnStmt(stmt *Return) (interface{}, error) {
 	if r.currentFunction == NoneFT {
 		r.runtime.ErrorTokenMessage(stmt.Keyword, "Can't return from top-level code.")
@@ -154,6 +229,27 @@ func (r *Resolver) visitReturnStmt(stmt *Return) (interface{}, error) {
 	return nil, nil
 }
 
+func (r *Resolver) visitTryStmt(stmt *Try) (interface{}, error) {
+	_, err := r.resolveStmt(stmt.Body)
+	if err != nil {
+		return nil, err
+	}
+	for _, handler := range stmt.Handlers {
+		_, err = r.resolveStmt(handler)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if stmt.FinallyBranch != nil {
+		_, err = r.resolveStmt(stmt.FinallyBranch)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
+
 func (r *Resolver) visitWhileStmt(stmt *While) (interface{}, error) {
 	_, err := r.resolveExpr(stmt.Condition)
 	if err != nil {
@@ -163,6 +259,32 @@ func (r *Resolver) visitWhileStmt(stmt *While) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	if stmt.Increment != nil {
+		_, err = r.resolveExpr(stmt.Increment)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *Resolver) visitYieldStmt(stmt *Yield) (interface{}, error) {
+	if r.currentFunction == NoneFT {
+		r.runtime.ErrorTokenMessage(stmt.Keyword, "Can't yield from top-level code.")
+	} else if r.currentFunction == InitializerFT {
+		r.runtime.ErrorTokenMessage(stmt.Keyword, "Can't yield from an initializer.")
+	} else {
+		// function which has yield returns generator when it's called
+		r.runtime.Generators[r.currentDecl] = true
+	}
+
+	if stmt.Value != nil {
+		_, err := r.resolveExpr(stmt.Value)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	return nil, nil
 }
@@ -178,7 +300,9 @@ func (r *Resolver) visitVarStmt(stmt *Var) (interface{}, error) {
 
 func (r *Resolver) visitAssignExpr(expr *Assign) (interface{}, error) {
 	r.resolveExpr(expr.Value)
-	return nil, r.resolveLocal(expr, expr.Name)
+	// variable which isn't found in local scopes is global
+	r.resolveLocal(expr, expr.Name)
+	return nil, nil
 }
 
 func (r *Resolver) visitBinaryExpr(expr *Binary) (interface{}, error) {
@@ -211,6 +335,34 @@ func (r *Resolver) visitCallExpr(expr *Call) (interface{}, error) {
 	return nil, nil
 }
 
+func (r *Resolver) visitComparisonExpr(expr *Comparison) (interface{}, error) {
+	for _, operand := range expr.Operands {
+		_, err := r.resolveExpr(operand)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *Resolver) visitConditionalExpr(expr *Conditional) (interface{}, error) {
+	_, err := r.resolveExpr(expr.Condition)
+	if err != nil {
+		return nil, err
+	}
+	_, err = r.resolveExpr(expr.ThenBranch)
+	if err != nil {
+		return nil, err
+	}
+	_, err = r.resolveExpr(expr.ElseBranch)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
 func (r *Resolver) visitGetExpr(expr *Get) (interface{}, error) {
 	_, err := r.resolveExpr(expr.Object)
 	if err != nil {
@@ -227,6 +379,28 @@ func (r *Resolver) visitGroupingExpr(expr *Grouping) (interface{}, error) {
 	return nil, nil
 }
 
+func (r *Resolver) visitIndexExpr(expr *Index) (interface{}, error) {
+	_, err := r.resolveExpr(expr.Object)
+	if err != nil {
+		return nil, err
+	}
+	_, err = r.resolveExpr(expr.Index)
+	if err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (r *Resolver) visitListExpr(expr *List) (interface{}, error) {
+	for _, element := range expr.Elements {
+		_, err := r.resolveExpr(element)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
 func (r *Resolver) visitLiteralExpr(expr *Literal) (interface{}, error) {
 	return nil, nil
 }
@@ -243,6 +417,20 @@ func (r *Resolver) visitLogicalExpr(expr *Logical) (interface{}, error) {
 	return nil, nil
 }
 
+func (r *Resolver) visitMapExpr(expr *Map) (interface{}, error) {
+	for k := range expr.Keys {
+		_, err := r.resolveExpr(expr.Keys[k])
+		if err != nil {
+			return nil, err
+		}
+		_, err = r.resolveExpr(expr.Values[k])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
 func (r *Resolver) visitSetExpr(expr *Set) (interface{}, error) {
 	_, err := r.resolveExpr(expr.Value)
 	if err != nil {
@@ -255,6 +443,43 @@ func (r *Resolver) visitSetExpr(expr *Set) (interface{}, error) {
 	return nil, nil
 }
 
+func (r *Resolver) visitSetIndexExpr(expr *SetIndex) (interface{}, error) {
+	_, err := r.resolveExpr(expr.Value)
+	if err != nil {
+		return nil, err
+	}
+	_, err = r.resolveExpr(expr.Object)
+	if err != nil {
+		return nil, err
+	}
+	_, err = r.resolveExpr(expr.Index)
+	if err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (r *Resolver) visitSliceExpr(expr *Slice) (interface{}, error) {
+	_, err := r.resolveExpr(expr.Object)
+	if err != nil {
+		return nil, err
+	}
+	for _, bound := range []Expr{expr.Start, expr.Stop} {
+		if bound == nil {
+			continue
+		}
+		_, err = r.resolveExpr(bound)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+func (r *Resolver) visitSpreadExpr(expr *Spread) (interface{}, error) {
+	return r.resolveExpr(expr.Value)
+}
+
 func (r *Resolver) visitSuperExpr(expr *Super) (interface{}, error) {
 	if r.currentClass == NoneCT {
 		r.runtime.ErrorTokenMessage(expr.Keyword, "Can't use 'super' outside of a class.")
@@ -312,20 +537,54 @@ func (r *Resolver) resolveExpr(expr Expr) (interface{}, error) {
 	return expr.Accept(r)
 }
 
+func (r *Resolver) visitInterpolationExpr(expr *Interpolation) (interface{}, error) {
+	for _, part := range expr.Parts {
+		_, err := r.resolveExpr(part)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+func (r *Resolver) visitKeywordArgExpr(expr *KeywordArg) (interface{}, error) {
+	return r.resolveExpr(expr.Value)
+}
+
+func (r *Resolver) visitLambdaExpr(expr *Lambda) (interface{}, error) {
+	r.resolveFunction(expr.Function, FunctionFT)
+	return nil, nil
+}
+
 func (r *Resolver) resolveFunction(function *Function, typ FunctionType) (interface{}, error) {
 	enclosingFunction := r.currentFunction
+	enclosingDecl := r.currentDecl
+	enclosingLoop := r.currentLoop
 	r.currentFunction = typ
+	r.currentDecl = function
+	// loop outside the function can't be controlled from the function body
+	r.currentLoop = NoneBlock
 	r.beginScope()
-	for _, param := range function.Params {
+	for k, param := range function.Params {
+		// default value can refer preceding parameters
+		if k < len(function.Defaults) && function.Defaults[k] != nil {
+			r.resolveExpr(function.Defaults[k])
+		}
 		r.declare(param)
 		r.define(param)
 	}
+	if function.Rest != nil {
+		r.declare(function.Rest)
+		r.define(function.Rest)
+	}
 	_, err := r.ResolveStmts(function.Body)
 	if err != nil {
 		return nil, err
 	}
 	r.endScope()
 	r.currentFunction = enclosingFunction
+	r.currentDecl = enclosingDecl
+	r.currentLoop = enclosingLoop
 	return nil, nil
 }
 
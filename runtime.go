@@ -1,35 +1,66 @@
 package tlps
 
 import (
+	"bufio"
 	"bytes"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 // Runtime is struct of Runtime
 type Runtime struct {
 	HadError        bool
 	HadRuntimeError bool
-	Globals         *Environment
+	Builtins        *Environment // builtin functions and classes shared by all modules
+	Globals         *Environment // top-level environment of the main script
 	Environment     *Environment
 	Locals          map[Expr]int
+	Generators      map[*Function]bool // functions which have yield
 	Scopes          *ScopeStack
+	CallStack       *CallStack
 	BasePath        string
+	SearchPath      []string                         // directories to search files to be included or imported
+	Stdin           *bufio.Reader                    // standard input shared by scripts and REPL
+	File            string                           // file name of the script currently executed
+	Sources         map[string][]byte                // source code of each file to show in error messages
+	Modules         map[string]*TLPSModule           // imported modules by absolute path
+	Including       []string                         // canonical paths of files being included. the first one is the outermost file
+	Included        map[*Environment]map[string]bool // files already included into each environment
+
+	diagnostics *[]string // collects syntax errors instead of printing them while Exec runs
+
+	// builtin exception classes defined in prelude
+	ExceptionClass     *TLPSClass
+	RuntimeErrorClass  *TLPSClass
+	StopIterationClass *TLPSClass
 }
 
 // NewRuntime is constructor of Runtime
 func NewRuntime() *Runtime {
-	globals := NewEnvironment(nil)
+	builtins := NewEnvironment(nil)
+	globals := NewEnvironment(builtins)
 	environment := globals
 
 	return &Runtime{
 		HadError:        false,
 		HadRuntimeError: false,
+		Builtins:        builtins,
 		Globals:         globals,
 		Environment:     environment,
 		Locals:          make(map[Expr]int),
+		Generators:      make(map[*Function]bool),
 		Scopes:          NewScopeStack(),
+		CallStack:       NewCallStack(),
 		BasePath:        "",
+		Stdin:           bufio.NewReader(os.Stdin),
+		File:            "<stdin>",
+		Sources:         make(map[string][]byte),
+		Modules:         make(map[string]*TLPSModule),
+		Included:        make(map[*Environment]map[string]bool),
 	}
 }
 
@@ -62,32 +93,221 @@ func (r *Runtime) Run(source *bytes.Buffer) {
 		return
 	}
 
+	r.CallStack.Push(NewCallFrame("<module>", r.File, 0))
+	defer r.CallStack.Pop()
 	interpreter.Interpret(statements)
 }
 
+// Exec runs script like Run, but it returns the first error instead of reporting it.
+// It is used to run included and imported files so that the includer can handle the error.
+// Syntax errors are returned as an error whose message has all of them.
+func (r *Runtime) Exec(source *bytes.Buffer) error {
+	previousHadError := r.HadError
+	previousDiagnostics := r.diagnostics
+	diagnostics := make([]string, 0)
+	r.HadError = false
+	r.diagnostics = &diagnostics
+	defer func() {
+		r.HadError = previousHadError
+		r.diagnostics = previousDiagnostics
+	}()
+
+	tokens := NewScanner(r, source).ScanTokens()
+	statements, _ := NewParser(r, tokens).Parse()
+	interpreter := NewInterpreter(r)
+	if !r.HadError {
+		NewResolver(r, interpreter).ResolveStmts(statements)
+	}
+	if r.HadError {
+		return errors.New(strings.Join(diagnostics, "\n"))
+	}
+
+	r.CallStack.Push(NewCallFrame("<module>", r.File, 0))
+	defer r.CallStack.Pop()
+	for _, statement := range statements {
+		if _, err := interpreter.execute(statement); err != nil {
+			interpreter.attachTraceback(err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// InTripleQuote checks that source ends inside of triple-quoted string.
+// REPL uses it to decide whether the next line continues the string.
+// Errors found while scanning aren't reported.
+func (r *Runtime) InTripleQuote(source string) bool {
+	previousHadError := r.HadError
+	previousDiagnostics := r.diagnostics
+	previousSource, hasSource := r.Sources[r.File]
+	diagnostics := make([]string, 0)
+	r.diagnostics = &diagnostics
+	defer func() {
+		r.HadError = previousHadError
+		r.diagnostics = previousDiagnostics
+		if hasSource {
+			r.Sources[r.File] = previousSource
+		} else {
+			delete(r.Sources, r.File)
+		}
+	}()
+
+	scanner := NewScanner(r, bytes.NewBufferString(source))
+	scanner.ScanTokens()
+	return scanner.inTriple
+}
+
 // ErrorMessage prints error massage at stderr
 func (r *Runtime) ErrorMessage(line int, message string) {
-	r.report(line, "", message)
+	r.report(NewToken(NilTT, "", nil, line), "", message)
 }
 
 // ErrorTokenMessage prints error message at stderr
 func (r *Runtime) ErrorTokenMessage(token *Token, message string) {
 	if token.Type == EOFTT {
-		r.report(token.Line, " at end", message)
+		r.report(token, " at end", message)
+	} else if token.Type == IndentTT {
+		r.report(token, " at indent", message)
+	} else if token.Type == DedentTT {
+		r.report(token, " at dedent", message)
 	} else {
-		r.report(token.Line, " at '"+token.Lexeme+"'", message)
+		r.report(token, " at '"+token.Lexeme+"'", message)
 	}
 }
 
+// ErrorPositionMessage prints error message pointing the position of token at stderr
+func (r *Runtime) ErrorPositionMessage(token *Token, message string) {
+	r.report(token, "", message)
+}
+
 // Report prints error masseg at stderr
-func (r *Runtime) report(line int, where string, message string) {
-	fmt.Fprintln(os.Stderr, "[line "+fmt.Sprint(line)+"] Error"+where+": "+message)
+func (r *Runtime) report(token *Token, where string, message string) {
+	var b strings.Builder
+	if token.Column == 0 {
+		fmt.Fprintln(&b, "[line "+fmt.Sprint(token.Line)+"] Error"+where+": "+message)
+	} else {
+		fmt.Fprintf(&b, "%v:%v:%v: Error%v: %v\n", token.File, token.Line, token.Column, where, message)
+		if code, marker, ok := r.snippet(token); ok {
+			gutter := fmt.Sprintf("%5d | ", token.Line)
+			fmt.Fprintln(&b, gutter+code)
+			fmt.Fprintln(&b, strings.Repeat(" ", len(gutter)-2)+"| "+marker)
+		}
+	}
+	if r.diagnostics != nil {
+		*r.diagnostics = append(*r.diagnostics, strings.TrimSuffix(b.String(), "\n"))
+	} else {
+		fmt.Fprint(os.Stderr, b.String())
+	}
 	r.HadError = true
 }
 
+// snippet returns the source line of token and the marker underlining the token
+func (r *Runtime) snippet(token *Token) (string, string, bool) {
+	src, ok := r.Sources[token.File]
+	if !ok || token.Column == 0 || token.Offset > len(src) {
+		return "", "", false
+	}
+
+	// source may be replaced, e.g., by next input of REPL
+	switch token.Type {
+	case IndentTT, DedentTT, NewlineTT, EOFTT:
+	default:
+		if !bytes.HasPrefix(src[token.Offset:], []byte(token.Lexeme)) {
+			return "", "", false
+		}
+	}
+
+	begin := bytes.LastIndexByte(src[:token.Offset], '\n') + 1
+	end := len(src)
+	if k := bytes.IndexByte(src[token.Offset:], '\n'); k >= 0 {
+		end = token.Offset + k
+	}
+	code := string(src[begin:end])
+
+	// keep tabs and count wide characters as two columns so that the marker is aligned with the code
+	marker := make([]rune, 0)
+	for _, c := range string(src[begin:token.Offset]) {
+		if c == '\t' {
+			marker = append(marker, '\t')
+		} else {
+			for k := 0; k < runeWidth(c); k++ {
+				marker = append(marker, ' ')
+			}
+		}
+	}
+	marker = append(marker, '^')
+
+	width := 1
+	switch token.Type {
+	case IndentTT, DedentTT, NewlineTT, EOFTT:
+	default:
+		// the underline of token spanning lines is cut at the end of line
+		code := []rune(string(src[token.Offset:end]))
+		if n := utf8.RuneCountInString(token.Lexeme); n < len(code) {
+			code = code[:n]
+		}
+		width = 0
+		for _, c := range code {
+			width += runeWidth(c)
+		}
+	}
+	for k := 1; k < width; k++ {
+		marker = append(marker, '~')
+	}
+
+	return code, string(marker), true
+}
+
+// runeWidth returns the number of columns which the character occupies in terminal.
+// East Asian wide characters such as kanji occupy two columns.
+func runeWidth(c rune) int {
+	switch {
+	case c >= 0xFF61 && c <= 0xFF9F: // halfwidth katakana
+		return 1
+	case unicode.In(c, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul),
+		c >= 0x3000 && c <= 0x303E, // CJK symbols and punctuation
+		c >= 0xFF01 && c <= 0xFF60, // fullwidth forms
+		c >= 0xFFE0 && c <= 0xFFE6:
+		return 2
+	}
+	return 1
+}
+
 // RuntimeError is error of runtime
 func (r *Runtime) RuntimeError(err error) {
-	e := err.(*CustomError)
-	fmt.Fprint(os.Stderr, err.Error()+"\n[line "+fmt.Sprint(e.Token.Line)+"]")
+	line := 0
+	var token *Token
+	var trace Traceback
+	switch e := err.(type) {
+	case *CustomError:
+		token = e.Token
+		line = e.Token.Line
+		trace = e.Trace
+	case *ExceptionValue:
+		token = e.Token
+		line = e.Token.Line
+		trace = e.Trace
+		// report the line where the exception is raised at first
+		if l, ok := e.Value.Fields["line"].(int64); ok {
+			line = int(l)
+		}
+	}
+	if trace == nil {
+		trace = r.CallStack.Traceback(line)
+	}
+
+	if trace == nil {
+		fmt.Fprint(os.Stderr, err.Error()+"\n[line "+fmt.Sprint(line)+"]")
+	} else {
+		fmt.Fprintln(os.Stderr, trace.String())
+		if token != nil && token.Line == line {
+			if code, marker, ok := r.snippet(token); ok {
+				n := len(code) - len(strings.TrimLeft(code, " \t"))
+				fmt.Fprintln(os.Stderr, "    "+code[n:]+"\n    "+marker[n:])
+			}
+		}
+		fmt.Fprintln(os.Stderr, err.Error())
+	}
 	r.HadRuntimeError = true
 }
@@ -0,0 +1,69 @@
+package tlps_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/goropikari/tlps"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuntime_Snippet(t *testing.T) {
+	var tests = []struct {
+		name     string
+		expected string
+		code     string
+	}{
+		{
+			name: "narrow multi-byte characters before token",
+			expected: "hoge.tlps:1:12: Error at '1': Expect ')' after arguments.\n" +
+				"    1 | print(\"éa\" 1)\n" +
+				"      |            ^",
+			code: "print(\"éa\" 1)\n",
+		},
+		{
+			name: "wide characters before token",
+			expected: "hoge.tlps:1:12: Error at '1': Expect ')' after arguments.\n" +
+				"    1 | print(\"あい\" 1)\n" +
+				"      |              ^",
+			code: "print(\"あい\" 1)\n",
+		},
+		{
+			name: "wide token",
+			expected: "hoge.tlps:1:10: Error at '変数': Expect ')' after arguments.\n" +
+				"    1 | print(変数 変数)\n" +
+				"      |            ^~~~",
+			code: "print(変数 変数)\n",
+		},
+		{
+			name: "tabs",
+			expected: "hoge.tlps:2:13: Error at '3': Expect ')' after arguments.\n" +
+				"    2 | \tprint(1,\t2 3)\n" +
+				"      | \t        \t  ^",
+			code: "var x = 1\n\tprint(1,\t2 3)\n",
+		},
+		{
+			name: "end of file",
+			expected: "hoge.tlps:1:8: Error at end: Expect ')' after arguments.\n" +
+				"    1 | print(1\n" +
+				"      |        ^",
+			code: "print(1",
+		},
+		{
+			name: "newline",
+			expected: "hoge.tlps:1:8: Error at '\\n': Expect expression.\n" +
+				"    1 | x = 1 +\n" +
+				"      |        ^",
+			code: "x = 1 +\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			r := tlps.NewRuntime()
+			r.File = "hoge.tlps"
+			err := r.Exec(bytes.NewBufferString(tt.code))
+			assert.EqualError(t, err, tt.expected)
+		})
+	}
+}
@@ -2,7 +2,9 @@ package tlps
 
 import (
 	"bytes"
+	"math/big"
 	"strconv"
+	"strings"
 	"unicode"
 )
 
@@ -11,49 +13,85 @@ type Scanner struct {
 	keywords    map[string]TokenType
 	indent      *IndentStack
 	isFirst     bool // use when count indentation level
+	nesting     int  // depth of brackets and braces. newlines are ignored inside them
+	inTriple    bool // source ends inside of triple-quoted string
+	depth       int  // depth of all brackets, braces and parentheses
+	bodies      []lambdaBody
 	runtime     *Runtime
 	source      *bytes.Buffer
 	sourceRunes []rune
 	tokens      TokenList
+	file        string
 	start       int
 	current     int
 	line        int
+
+	// position of the token being scanned
+	startLine   int
+	startColumn int
+	startOffset int
+	offset      int // byte offset of current
+	lineStart   int // index of first rune of current line
+}
+
+// lambdaBody is indented body of lambda written inside brackets.
+// Newlines are significant in it until it ends at dedent, ',' or closing bracket.
+type lambdaBody struct {
+	depth   int  // depth of brackets where lambda is written
+	nesting int  // nesting restored at the end of body
+	indent  int  // indentation level of the line where lambda is written
+	silent  bool // indent level is pushed without indent token
 }
 
 // NewScanner is constructor of Scanner
 func NewScanner(r *Runtime, b *bytes.Buffer) *Scanner {
 	var keywords = map[string]TokenType{
-		"and":     AndTT,
-		"class":   ClassTT,
-		"else":    ElseTT,
-		"elseif":  ElseifTT,
-		"false":   FalseTT,
-		"for":     ForTT,
-		"fun":     FunTT,
-		"if":      IfTT,
-		"include": IncludeTT,
-		"nil":     NilTT,
-		"or":      OrTT,
-		"pass":    PassTT,
-		"return":  ReturnTT,
-		"super":   SuperTT,
-		"this":    ThisTT,
-		"true":    TrueTT,
-		"var":     VarTT,
-		"while":   WhileTT,
+		"and":      AndTT,
+		"as":       AsTT,
+		"break":    BreakTT,
+		"class":    ClassTT,
+		"continue": ContinueTT,
+		"else":     ElseTT,
+		"elseif":   ElseifTT,
+		"except":   ExceptTT,
+		"false":    FalseTT,
+		"finally":  FinallyTT,
+		"for":      ForTT,
+		"fun":      FunTT,
+		"if":       IfTT,
+		"import":   ImportTT,
+		"in":       InTT,
+		"include":  IncludeTT,
+		"nil":      NilTT,
+		"or":       OrTT,
+		"pass":     PassTT,
+		"raise":    RaiseTT,
+		"return":   ReturnTT,
+		"super":    SuperTT,
+		"this":     ThisTT,
+		"true":     TrueTT,
+		"try":      TryTT,
+		"var":      VarTT,
+		"while":    WhileTT,
+		"yield":    YieldTT,
 	}
 
 	indent := NewIndentStack()
 	indent.Push(0)
 
+	// remember source to show it in error messages
+	r.Sources[r.File] = append([]byte(nil), b.Bytes()...)
+
 	return &Scanner{
 		keywords:    keywords,
 		indent:      indent,
 		isFirst:     true,
+		nesting:     0,
 		runtime:     r,
 		source:      b,
 		sourceRunes: bytes.Runes(b.Bytes()),
 		tokens:      []*Token{},
+		file:        r.File,
 		start:       0,
 		current:     0,
 		line:        1,
@@ -65,16 +103,18 @@ func (s *Scanner) ScanTokens() TokenList {
 	for !s.isAtEnd() {
 		s.addBlock()
 		s.start = s.current
+		s.markStart()
 		s.scanToken()
 		s.removeUselessNewline()
 	}
 
-	for s.indent.Peek() != 0 {
+	s.markStart()
+	for s.indent.Peek() > 0 {
 		s.indent.Pop()
-		s.tokens = append(s.tokens, NewToken(RightBraceTT, "}", nil, s.line))
+		s.tokens = append(s.tokens, s.newToken(DedentTT, "<dedent>", nil))
 	}
 
-	s.tokens = append(s.tokens, NewToken(EOFTT, "", nil, s.line))
+	s.tokens = append(s.tokens, s.newToken(EOFTT, "", nil))
 	return s.tokens
 }
 
@@ -83,18 +123,42 @@ func (s *Scanner) scanToken() {
 
 	switch c {
 	case '(':
+		s.depth++
 		s.addToken(LeftParenTT, nil)
 		break
 	case ')':
+		s.endLambdaBody()
+		s.depth--
 		s.addToken(RightParenTT, nil)
 		break
-	// case '{':
-	// 	s.addToken(LeftBraceTT, nil)
-	// 	break
-	// case '}':
-	// 	s.addToken(RightBraceTT, nil)
-	// 	break
+	case '{':
+		s.depth++
+		s.nesting++
+		s.addToken(LeftBraceTT, nil)
+		break
+	case '}':
+		s.endLambdaBody()
+		s.depth--
+		if s.nesting > 0 {
+			s.nesting--
+		}
+		s.addToken(RightBraceTT, nil)
+		break
+	case '[':
+		s.depth++
+		s.nesting++
+		s.addToken(LeftBracketTT, nil)
+		break
+	case ']':
+		s.endLambdaBody()
+		s.depth--
+		if s.nesting > 0 {
+			s.nesting--
+		}
+		s.addToken(RightBracketTT, nil)
+		break
 	case ',':
+		s.endLambdaBody()
 		s.addToken(CommaTT, nil)
 		break
 	case '.':
@@ -110,10 +174,32 @@ func (s *Scanner) scanToken() {
 		s.addToken(SemicolonTT, nil)
 		break
 	case ':':
+		s.beginLambdaBody()
 		s.addToken(ColonTT, nil)
 		break
 	case '*':
-		s.addToken(StarTT, nil)
+		var tt TokenType
+		if s.match('*') {
+			tt = StarStarTT
+		} else {
+			tt = StarTT
+		}
+		s.addToken(tt, nil)
+		break
+	case '%':
+		s.addToken(PercentTT, nil)
+		break
+	case '&':
+		s.addToken(AmpersandTT, nil)
+		break
+	case '|':
+		s.addToken(PipeTT, nil)
+		break
+	case '^':
+		s.addToken(CaretTT, nil)
+		break
+	case '~':
+		s.addToken(TildeTT, nil)
 		break
 	case '!':
 		var tt TokenType
@@ -135,6 +221,8 @@ func (s *Scanner) scanToken() {
 		var tt TokenType
 		if s.match('=') {
 			tt = LessEqualTT
+		} else if s.match('<') {
+			tt = LessLessTT
 		} else {
 			tt = LessTT
 		}
@@ -143,18 +231,25 @@ func (s *Scanner) scanToken() {
 		var tt TokenType
 		if s.match('=') {
 			tt = GreaterEqualTT
+		} else if s.match('>') {
+			tt = GreaterGreaterTT
 		} else {
 			tt = GreaterTT
 		}
 		s.addToken(tt, nil)
 	case '/':
+		var tt TokenType
 		if s.match('/') {
-			// A comment goes until the end of the line.
-			for s.peek() != '\n' && !s.isAtEnd() {
-				s.advance()
-			}
+			tt = SlashSlashTT
 		} else {
-			s.addToken(SlashTT, nil)
+			tt = SlashTT
+		}
+		s.addToken(tt, nil)
+		break
+	case '#':
+		// A comment goes until the end of the line.
+		for s.peek() != '\n' && !s.isAtEnd() {
+			s.advance()
 		}
 		break
 	case ' ':
@@ -162,18 +257,29 @@ func (s *Scanner) scanToken() {
 	case '\t':
 		break
 	case '\n':
-		s.addNewline()
+		if s.nesting > 0 {
+			// implicit line joining inside brackets and braces
+			s.line++
+		} else {
+			s.addNewline()
+		}
 		break
 	case '"':
-		s.addString()
+		if s.peek() == '"' && s.peekNext() == '"' {
+			s.advance()
+			s.advance()
+			s.addTripleQuotedString(false)
+		} else {
+			s.addString()
+		}
 		break
 	default:
 		if unicode.IsDigit(c) {
 			s.addNumber()
-		} else if unicode.IsLetter(c) {
+		} else if unicode.IsLetter(c) || c == '_' {
 			s.addIdentifier()
 		} else {
-			s.runtime.ErrorMessage(s.line, "Unexpected character.")
+			s.runtime.ErrorPositionMessage(s.newToken(IdentifierTT, string(c), nil), "Unexpected character.")
 		}
 		break
 	}
@@ -213,18 +319,39 @@ func (s *Scanner) match(expected rune) bool {
 func (s *Scanner) advance() (rune, int, error) {
 	r, size, err := s.source.ReadRune()
 	s.current++
+	s.offset += size
+	if r == '\n' {
+		s.lineStart = s.current
+	}
 
 	return r, size, err
 }
 
+// markStart records current position as the beginning of next token
+func (s *Scanner) markStart() {
+	s.startLine = s.line
+	s.startColumn = s.current - s.lineStart + 1
+	s.startOffset = s.offset
+}
+
+// newToken creates token located at the position recorded by markStart
+func (s *Scanner) newToken(tt TokenType, lexeme string, literal interface{}) *Token {
+	token := NewToken(tt, lexeme, literal, s.startLine)
+	token.Column = s.startColumn
+	token.Offset = s.startOffset
+	token.File = s.file
+
+	return token
+}
+
 func (s *Scanner) addToken(tt TokenType, literal interface{}) {
 	s.isFirst = false
 	text := string(s.sourceRunes[s.start:s.current])
-	s.tokens = append(s.tokens, NewToken(tt, text, literal, s.line))
+	s.tokens = append(s.tokens, s.newToken(tt, text, literal))
 }
 
 func (s *Scanner) addNewline() {
-	s.tokens = append(s.tokens, NewToken(NewlineTT, "\\n", nil, s.line))
+	s.tokens = append(s.tokens, s.newToken(NewlineTT, "\\n", nil))
 	s.line++
 	s.isFirst = true
 }
@@ -240,14 +367,26 @@ func (s *Scanner) addBlock() {
 	}
 
 	// skip comment or empty line
-	if s.peek() == '\n' || (s.peek() == '/' && s.peekNext() == '/') {
+	if s.peek() == '\n' || s.peek() == '#' {
 		return
 	}
 
+	// indentation tokens point to the first character of the line
+	s.markStart()
+
+	// lambda body inside brackets ends at dedent to the line where lambda is written
+	for n := len(s.bodies); n > 0 && depth <= s.bodies[n-1].indent; n = len(s.bodies) {
+		s.closeLambdaBody()
+		if s.nesting > 0 {
+			// indentation is ignored inside brackets and braces
+			return
+		}
+	}
+
 	d := s.indent.Peek()
 	if d < depth {
 		s.indent.Push(depth)
-		s.tokens = append(s.tokens, NewToken(LeftBraceTT, "{", nil, s.line))
+		s.tokens = append(s.tokens, s.newToken(IndentTT, "<indent>", nil))
 	} else if d > depth {
 		cnt := 0
 		for s.indent.Pop() != -1 {
@@ -258,13 +397,80 @@ func (s *Scanner) addBlock() {
 		}
 
 		if s.indent.IsEmpty() {
-			s.runtime.ErrorMessage(s.line, "unindent does not match any outer indentation level")
+			s.runtime.ErrorPositionMessage(s.newToken(DedentTT, "<dedent>", nil), "unindent does not match any outer indentation level")
 		}
 
 		for i := 0; i < cnt; i++ {
-			s.tokens = append(s.tokens, NewToken(RightBraceTT, "}", nil, s.line))
+			s.tokens = append(s.tokens, s.newToken(DedentTT, "<dedent>", nil))
+		}
+	}
+}
+
+// beginLambdaBody starts lambda body when "):" inside brackets is followed by line break.
+// Newlines inside the body are significant even in brackets and braces.
+func (s *Scanner) beginLambdaBody() {
+	if s.depth == 0 || len(s.tokens) == 0 || s.tokens[len(s.tokens)-1].Type != RightParenTT {
+		return
+	}
+	if n := len(s.bodies); n > 0 && s.bodies[n-1].depth == s.depth {
+		return
+	}
+
+	n := 0
+	for s.peekAt(n) == ' ' || s.peekAt(n) == '\t' || s.peekAt(n) == '\r' {
+		n++
+	}
+	if c := s.peekAt(n); c != '\n' && c != '#' && c != 0 {
+		return
+	}
+
+	// indentation of lines inside brackets isn't counted yet,
+	// so the line where lambda is written becomes a level without indent token
+	body := lambdaBody{depth: s.depth, nesting: s.nesting, indent: s.indent.Peek()}
+	if s.nesting > 0 {
+		if level := s.lineIndent(); level > body.indent {
+			s.indent.Push(level)
+			body.indent = level
+			body.silent = true
 		}
 	}
+	s.bodies = append(s.bodies, body)
+	s.nesting = 0
+}
+
+// lineIndent returns indentation of current line
+func (s *Scanner) lineIndent() int {
+	level := 0
+	for s.lineStart+level < len(s.sourceRunes) && s.sourceRunes[s.lineStart+level] == ' ' {
+		level++
+	}
+	return level
+}
+
+// endLambdaBody terminates lambda body when ',' or closing bracket follows it on the same line
+// so that rest of arguments or elements can be written after the body.
+func (s *Scanner) endLambdaBody() {
+	if n := len(s.bodies); n > 0 && s.bodies[n-1].depth == s.depth {
+		s.closeLambdaBody()
+	}
+}
+
+// closeLambdaBody emits newline and dedents which terminate innermost lambda body
+func (s *Scanner) closeLambdaBody() {
+	body := s.bodies[len(s.bodies)-1]
+	s.bodies = s.bodies[:len(s.bodies)-1]
+
+	if len(s.tokens) > 0 && s.tokens[len(s.tokens)-1].Type != NewlineTT {
+		s.tokens = append(s.tokens, s.newToken(NewlineTT, "\\n", nil))
+	}
+	for s.indent.Peek() > body.indent {
+		s.indent.Pop()
+		s.tokens = append(s.tokens, s.newToken(DedentTT, "<dedent>", nil))
+	}
+	if body.silent {
+		s.indent.Pop()
+	}
+	s.nesting = body.nesting
 }
 
 func (s *Scanner) addString() {
@@ -282,7 +488,7 @@ func (s *Scanner) addString() {
 	}
 
 	if s.isAtEnd() {
-		s.runtime.ErrorMessage(s.line, "Unterminated string.")
+		s.runtime.ErrorPositionMessage(s.newToken(StringTT, "\"", nil), "Unterminated string.")
 		return
 	}
 
@@ -294,6 +500,206 @@ func (s *Scanner) addString() {
 	s.addToken(StringTT, string(value))
 }
 
+// addRawString scans raw string such as r"C:\path".
+// Escape sequences are not processed, so raw string can't contain '"'.
+func (s *Scanner) addRawString() {
+	for s.peek() != '"' && !s.isAtEnd() {
+		if s.peek() == '\n' {
+			s.line++
+		}
+		s.advance()
+	}
+
+	if s.isAtEnd() {
+		s.runtime.ErrorPositionMessage(s.newToken(StringTT, "r\"", nil), "Unterminated string.")
+		return
+	}
+
+	// The closing "
+	s.advance()
+
+	s.addToken(StringTT, string(s.sourceRunes[s.start+2:s.current-1]))
+}
+
+// addTripleQuotedString scans string enclosed by """ which can span lines.
+// Common leading indentation of lines is removed by dedent.
+// If raw is true, escape sequences are not processed.
+func (s *Scanner) addTripleQuotedString(raw bool) {
+	contentStart := s.current
+	for !s.isAtEnd() && !(s.peek() == '"' && s.peekNext() == '"' && s.peekAt(2) == '"') {
+		if s.peek() == '\\' && !raw {
+			// skip escaped character so that \" doesn't close the string
+			s.advance()
+		}
+		if s.peek() == '\n' {
+			s.line++
+		}
+		if !s.isAtEnd() {
+			s.advance()
+		}
+	}
+
+	if s.isAtEnd() {
+		s.inTriple = true
+		lexeme := string(s.sourceRunes[s.start:contentStart])
+		s.runtime.ErrorPositionMessage(s.newToken(StringTT, lexeme, nil), "Unterminated string.")
+		return
+	}
+
+	content := s.sourceRunes[contentStart:s.current]
+
+	// The closing """
+	s.advance()
+	s.advance()
+	s.advance()
+
+	value := dedent(string(content))
+	if !raw {
+		value = s.unescape([]rune(value))
+	}
+	s.addToken(StringTT, value)
+}
+
+// dedent removes common leading indentation of lines like text block of Java.
+// A newline right after the opening """ is removed.
+// If the closing """ is on its own line, its indentation is also taken into account.
+func dedent(text string) string {
+	text = strings.TrimPrefix(strings.TrimPrefix(text, "\r"), "\n")
+	lines := strings.Split(text, "\n")
+	last := len(lines) - 1
+
+	indent := -1
+	for k, line := range lines {
+		trimmed := strings.TrimLeft(line, " \t")
+		if trimmed == "" && k != last {
+			// blank lines don't affect indentation
+			continue
+		}
+		if n := len(line) - len(trimmed); indent < 0 || n < indent {
+			indent = n
+		}
+	}
+
+	for k, line := range lines {
+		if k == last && strings.TrimLeft(line, " \t") == "" {
+			lines[k] = ""
+		} else if len(line) >= indent {
+			lines[k] = line[indent:]
+		} else {
+			lines[k] = strings.TrimLeft(line, " \t")
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// addFString scans f-string such as f"count: {n + 1}".
+// Text parts are added as StringTT, and embedded expressions are scanned as ordinary tokens
+// enclosed by InterpolationStartTT and InterpolationEndTT.
+func (s *Scanner) addFString() {
+	s.addToken(FStringStartTT, nil)
+	start := s.tokens[len(s.tokens)-1]
+
+	for {
+		s.start = s.current
+		s.markStart()
+		value := s.fstringText()
+		if s.current > s.start {
+			s.addToken(StringTT, value)
+		}
+
+		if s.isAtEnd() {
+			s.unterminatedFString(start)
+			return
+		}
+
+		s.start = s.current
+		s.markStart()
+		if c, _, _ := s.advance(); c == '"' {
+			s.addToken(FStringEndTT, nil)
+			return
+		}
+		s.addToken(InterpolationStartTT, nil)
+
+		// newlines are ignored in embedded expression as in brackets
+		s.nesting++
+		depth := s.nesting
+		for !s.isAtEnd() && !(s.peek() == '}' && s.nesting == depth) {
+			s.start = s.current
+			s.markStart()
+			s.scanToken()
+		}
+		s.nesting = depth - 1
+
+		if s.isAtEnd() {
+			s.unterminatedFString(start)
+			return
+		}
+		s.start = s.current
+		s.markStart()
+		s.advance()
+		s.addToken(InterpolationEndTT, nil)
+	}
+}
+
+// unterminatedFString reports error and drops tokens of the f-string as addString does
+func (s *Scanner) unterminatedFString(start *Token) {
+	s.runtime.ErrorPositionMessage(start, "Unterminated string.")
+	for k := len(s.tokens) - 1; k >= 0; k-- {
+		if s.tokens[k] == start {
+			s.tokens = s.tokens[:k]
+			return
+		}
+	}
+}
+
+// fstringText scans text part of f-string until '{' of embedded expression or closing '"'.
+// "{{" and "}}" are read as '{' and '}'.
+func (s *Scanner) fstringText() string {
+	value := make([]rune, 0)
+	for !s.isAtEnd() {
+		c := s.peek()
+		switch {
+		case c == '"':
+			return string(value)
+		case c == '{' && s.peekNext() != '{':
+			return string(value)
+		case c == '{' || c == '}' && s.peekNext() == '}':
+			s.advance()
+		case c == '}':
+			s.runtime.ErrorPositionMessage(s.positionToken(1), "Single '}' is not allowed in f-string.")
+		case c == '\\':
+			e, ok := escapeCharacter(s.peekNext())
+			if !ok {
+				s.runtime.ErrorPositionMessage(s.positionToken(2), "invalid escape sequence")
+			} else {
+				s.advance()
+				c = e
+			}
+		case c == '\n':
+			s.line++
+		}
+		s.advance()
+		value = append(value, c)
+	}
+
+	return string(value)
+}
+
+// positionToken creates token of n runes from current position to point it in error message
+func (s *Scanner) positionToken(n int) *Token {
+	end := s.current + n
+	if end > len(s.sourceRunes) {
+		end = len(s.sourceRunes)
+	}
+	token := NewToken(StringTT, string(s.sourceRunes[s.current:end]), nil, s.line)
+	token.Column = s.current - s.lineStart + 1
+	token.Offset = s.offset
+	token.File = s.file
+
+	return token
+}
+
 func (s *Scanner) addNumber() {
 	for unicode.IsDigit(s.peek()) {
 		s.advance()
@@ -307,10 +713,20 @@ func (s *Scanner) addNumber() {
 		for unicode.IsDigit(s.peek()) {
 			s.advance()
 		}
+
+		f, _ := strconv.ParseFloat(string(s.sourceRunes[s.start:s.current]), 64)
+		s.addToken(NumberTT, f)
+		return
 	}
 
-	f, _ := strconv.ParseFloat(string(s.sourceRunes[s.start:s.current]), 64)
-	s.addToken(NumberTT, f)
+	text := string(s.sourceRunes[s.start:s.current])
+	if n, err := strconv.ParseInt(text, 10, 64); err == nil {
+		s.addToken(NumberTT, n)
+		return
+	}
+	// too large for int64
+	n, _ := new(big.Int).SetString(text, 10)
+	s.addToken(NumberTT, n)
 }
 
 func (s *Scanner) addIdentifier() {
@@ -319,6 +735,23 @@ func (s *Scanner) addIdentifier() {
 	}
 
 	text := string(s.sourceRunes[s.start:s.current])
+	if text == "f" && s.peek() == '"' {
+		s.advance()
+		s.addFString()
+		return
+	}
+	if text == "r" && s.peek() == '"' {
+		s.advance()
+		if s.peek() == '"' && s.peekNext() == '"' {
+			s.advance()
+			s.advance()
+			s.addTripleQuotedString(true)
+		} else {
+			s.addRawString()
+		}
+		return
+	}
+
 	typ, ok := s.keywords[text]
 	if ok {
 		s.addToken(typ, nil)
@@ -350,33 +783,27 @@ func (s *Scanner) peekNext() rune {
 	return s.sourceRunes[s.current+1]
 }
 
+func (s *Scanner) peekAt(n int) rune {
+	if s.current+n >= len(s.sourceRunes) {
+		return 0
+	}
+	return s.sourceRunes[s.current+n]
+}
+
 func (s *Scanner) handleEscapeCharacter() string {
-	runes := s.sourceRunes[s.start+1 : s.current-1]
+	return s.unescape(s.sourceRunes[s.start+1 : s.current-1])
+}
+
+// unescape processes escape sequences in runes
+func (s *Scanner) unescape(runes []rune) string {
 	value := make([]rune, 0)
 	var prevc rune
 	for _, v := range runes {
 		var c rune
 		if prevc == '\\' {
-			// https://en.wikipedia.org/wiki/C_syntax#Backslash_escapes
-			switch v {
-			case '\\':
-				c = v
-			case '"':
-				c = v
-			case 'n':
-				c = '\n'
-			case 'r':
-				c = '\r'
-			case 'b':
-				c = '\b'
-			case 't':
-				c = '\t'
-			case 'f':
-				c = '\f'
-			case 'v':
-				c = '\v'
-			default:
-				s.runtime.ErrorMessage(s.line, "invalid escape sequence")
+			var ok bool
+			if c, ok = escapeCharacter(v); !ok {
+				s.runtime.ErrorPositionMessage(s.newToken(StringTT, string(s.sourceRunes[s.start:s.current]), nil), "invalid escape sequence")
 				return ""
 			}
 
@@ -396,3 +823,25 @@ func (s *Scanner) handleEscapeCharacter() string {
 
 	return string(value)
 }
+
+// escapeCharacter returns character represented by escape sequence \c
+func escapeCharacter(c rune) (rune, bool) {
+	// https://en.wikipedia.org/wiki/C_syntax#Backslash_escapes
+	switch c {
+	case '\\', '"':
+		return c, true
+	case 'n':
+		return '\n', true
+	case 'r':
+		return '\r', true
+	case 'b':
+		return '\b', true
+	case 't':
+		return '\t', true
+	case 'f':
+		return '\f', true
+	case 'v':
+		return '\v', true
+	}
+	return 0, false
+}
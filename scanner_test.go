@@ -19,45 +19,56 @@ func TestScanner(t *testing.T) {
 		{
 			name: "assign val",
 			expected: tlps.TokenList{
-				tlps.NewToken(tlps.IdentifierTT, "x", nil, 1),
-				tlps.NewToken(tlps.EqualTT, "=", nil, 1),
-				tlps.NewToken(tlps.NumberTT, "1", 1.0, 1),
-				tlps.NewToken(tlps.EOFTT, "", nil, 1),
+				newToken(tlps.IdentifierTT, "x", nil, 1, 1, 0),
+				newToken(tlps.EqualTT, "=", nil, 1, 3, 2),
+				newToken(tlps.NumberTT, "1", int64(1), 1, 5, 4),
+				newToken(tlps.EOFTT, "", nil, 1, 6, 5),
 			},
 			code: "x = 1",
 		},
+		{
+			name: "import",
+			expected: tlps.TokenList{
+				newToken(tlps.ImportTT, "import", nil, 1, 1, 0),
+				newToken(tlps.StringTT, "\"lib.tlps\"", "lib.tlps", 1, 8, 7),
+				newToken(tlps.AsTT, "as", nil, 1, 19, 18),
+				newToken(tlps.IdentifierTT, "_lib", nil, 1, 22, 21),
+				newToken(tlps.EOFTT, "", nil, 1, 26, 25),
+			},
+			code: `import "lib.tlps" as _lib`,
+		},
 		{
 			name: "if block",
 			expected: tlps.TokenList{
-				tlps.NewToken(tlps.IfTT, "if", nil, 1),
-				tlps.NewToken(tlps.IdentifierTT, "hoge", nil, 1),
-				tlps.NewToken(tlps.ColonTT, ":", nil, 1),
-				tlps.NewToken(tlps.NewlineTT, "\\n", nil, 1),
-				tlps.NewToken(tlps.LeftBraceTT, "{", nil, 2),
-				tlps.NewToken(tlps.IdentifierTT, "x", nil, 2),
-				tlps.NewToken(tlps.NewlineTT, "\\n", nil, 2),
-				tlps.NewToken(tlps.RightBraceTT, "}", nil, 3),
-				tlps.NewToken(tlps.ElseTT, "else", nil, 3),
-				tlps.NewToken(tlps.ColonTT, ":", nil, 3),
-				tlps.NewToken(tlps.NewlineTT, "\\n", nil, 3),
-				tlps.NewToken(tlps.LeftBraceTT, "{", nil, 4),
-				tlps.NewToken(tlps.IfTT, "if", nil, 4),
-				tlps.NewToken(tlps.IdentifierTT, "piyo", nil, 4),
-				tlps.NewToken(tlps.ColonTT, ":", nil, 4),
-				tlps.NewToken(tlps.NewlineTT, "\\n", nil, 4),
-				tlps.NewToken(tlps.LeftBraceTT, "{", nil, 5),
-				tlps.NewToken(tlps.IdentifierTT, "y", nil, 5),
-				tlps.NewToken(tlps.NewlineTT, "\\n", nil, 5),
-				tlps.NewToken(tlps.RightBraceTT, "}", nil, 6),
-				tlps.NewToken(tlps.ElseTT, "else", nil, 6),
-				tlps.NewToken(tlps.ColonTT, ":", nil, 6),
-				tlps.NewToken(tlps.NewlineTT, "\\n", nil, 6),
-				tlps.NewToken(tlps.LeftBraceTT, "{", nil, 7),
-				tlps.NewToken(tlps.IdentifierTT, "z", nil, 7),
-				tlps.NewToken(tlps.NewlineTT, "\\n", nil, 7),
-				tlps.NewToken(tlps.RightBraceTT, "}", nil, 8),
-				tlps.NewToken(tlps.RightBraceTT, "}", nil, 8),
-				tlps.NewToken(tlps.EOFTT, "", nil, 8),
+				newToken(tlps.IfTT, "if", nil, 1, 1, 0),
+				newToken(tlps.IdentifierTT, "hoge", nil, 1, 4, 3),
+				newToken(tlps.ColonTT, ":", nil, 1, 8, 7),
+				newToken(tlps.NewlineTT, "\\n", nil, 1, 9, 8),
+				newToken(tlps.IndentTT, "<indent>", nil, 2, 3, 11),
+				newToken(tlps.IdentifierTT, "x", nil, 2, 3, 11),
+				newToken(tlps.NewlineTT, "\\n", nil, 2, 4, 12),
+				newToken(tlps.DedentTT, "<dedent>", nil, 3, 1, 13),
+				newToken(tlps.ElseTT, "else", nil, 3, 1, 13),
+				newToken(tlps.ColonTT, ":", nil, 3, 5, 17),
+				newToken(tlps.NewlineTT, "\\n", nil, 3, 6, 18),
+				newToken(tlps.IndentTT, "<indent>", nil, 4, 3, 21),
+				newToken(tlps.IfTT, "if", nil, 4, 3, 21),
+				newToken(tlps.IdentifierTT, "piyo", nil, 4, 6, 24),
+				newToken(tlps.ColonTT, ":", nil, 4, 10, 28),
+				newToken(tlps.NewlineTT, "\\n", nil, 4, 11, 29),
+				newToken(tlps.IndentTT, "<indent>", nil, 5, 5, 34),
+				newToken(tlps.IdentifierTT, "y", nil, 5, 5, 34),
+				newToken(tlps.NewlineTT, "\\n", nil, 5, 6, 35),
+				newToken(tlps.DedentTT, "<dedent>", nil, 6, 3, 38),
+				newToken(tlps.ElseTT, "else", nil, 6, 3, 38),
+				newToken(tlps.ColonTT, ":", nil, 6, 7, 42),
+				newToken(tlps.NewlineTT, "\\n", nil, 6, 8, 43),
+				newToken(tlps.IndentTT, "<indent>", nil, 7, 5, 48),
+				newToken(tlps.IdentifierTT, "z", nil, 7, 5, 48),
+				newToken(tlps.NewlineTT, "\\n", nil, 7, 6, 49),
+				newToken(tlps.DedentTT, "<dedent>", nil, 8, 1, 50),
+				newToken(tlps.DedentTT, "<dedent>", nil, 8, 1, 50),
+				newToken(tlps.EOFTT, "", nil, 8, 1, 50),
 			},
 			code: "if hoge:\n  x\nelse:\n  if piyo:\n    y\n  else:\n    z\n",
 			// if hoge:
@@ -68,26 +79,108 @@ func TestScanner(t *testing.T) {
 			//   else:
 			//     z
 		},
+		{
+			name: "braces in block",
+			expected: tlps.TokenList{
+				newToken(tlps.IfTT, "if", nil, 1, 1, 0),
+				newToken(tlps.IdentifierTT, "x", nil, 1, 4, 3),
+				newToken(tlps.ColonTT, ":", nil, 1, 5, 4),
+				newToken(tlps.NewlineTT, "\\n", nil, 1, 6, 5),
+				newToken(tlps.IndentTT, "<indent>", nil, 2, 3, 8),
+				newToken(tlps.IdentifierTT, "m", nil, 2, 3, 8),
+				newToken(tlps.EqualTT, "=", nil, 2, 5, 10),
+				newToken(tlps.LeftBraceTT, "{", nil, 2, 7, 12),
+				newToken(tlps.StringTT, "\"a\"", "a", 3, 5, 18),
+				newToken(tlps.ColonTT, ":", nil, 3, 8, 21),
+				newToken(tlps.LeftBracketTT, "[", nil, 3, 10, 23),
+				newToken(tlps.NumberTT, "1", int64(1), 3, 11, 24),
+				newToken(tlps.RightBracketTT, "]", nil, 3, 12, 25),
+				newToken(tlps.CommaTT, ",", nil, 3, 13, 26),
+				newToken(tlps.RightBraceTT, "}", nil, 4, 1, 28),
+				newToken(tlps.NewlineTT, "\\n", nil, 4, 2, 29),
+				newToken(tlps.DedentTT, "<dedent>", nil, 5, 1, 30),
+				newToken(tlps.EOFTT, "", nil, 5, 1, 30),
+			},
+			code: "if x:\n  m = {\n    \"a\": [1],\n}\n",
+			// if x:
+			//   m = {
+			//     "a": [1],
+			// }
+		},
+		{
+			name: "operators",
+			expected: tlps.TokenList{
+				newToken(tlps.PercentTT, "%", nil, 1, 1, 0),
+				newToken(tlps.SlashSlashTT, "//", nil, 1, 3, 2),
+				newToken(tlps.SlashTT, "/", nil, 1, 6, 5),
+				newToken(tlps.StarStarTT, "**", nil, 1, 8, 7),
+				newToken(tlps.StarTT, "*", nil, 1, 11, 10),
+				newToken(tlps.AmpersandTT, "&", nil, 1, 13, 12),
+				newToken(tlps.PipeTT, "|", nil, 1, 15, 14),
+				newToken(tlps.CaretTT, "^", nil, 1, 17, 16),
+				newToken(tlps.TildeTT, "~", nil, 1, 19, 18),
+				newToken(tlps.LessLessTT, "<<", nil, 1, 21, 20),
+				newToken(tlps.LessEqualTT, "<=", nil, 1, 24, 23),
+				newToken(tlps.GreaterGreaterTT, ">>", nil, 1, 27, 26),
+				newToken(tlps.GreaterTT, ">", nil, 1, 30, 29),
+				newToken(tlps.EOFTT, "", nil, 1, 41, 40),
+			},
+			code: "% // / ** * & | ^ ~ << <= >> > # comment",
+		},
 		{
 			name: "unicode string",
 			expected: tlps.TokenList{
-				tlps.NewToken(tlps.IdentifierTT, "x", nil, 1),
-				tlps.NewToken(tlps.EqualTT, "=", nil, 1),
-				tlps.NewToken(tlps.StringTT, "\"hoge こんにちは\\\" piyo\"", "hoge こんにちは\" piyo", 1),
-				tlps.NewToken(tlps.EOFTT, "", nil, 1),
+				newToken(tlps.IdentifierTT, "x", nil, 1, 1, 0),
+				newToken(tlps.EqualTT, "=", nil, 1, 3, 2),
+				newToken(tlps.StringTT, "\"hoge こんにちは\\\" piyo\"", "hoge こんにちは\" piyo", 1, 5, 4),
+				newToken(tlps.EOFTT, "", nil, 1, 24, 33),
 			},
 			code: "x = \"hoge こんにちは\\\" piyo\"",
 		},
+		{
+			name: "f-string",
+			expected: tlps.TokenList{
+				newToken(tlps.FStringStartTT, "f\"", nil, 1, 1, 0),
+				newToken(tlps.StringTT, "a{{", "a{", 1, 3, 2),
+				newToken(tlps.InterpolationStartTT, "{", nil, 1, 6, 5),
+				newToken(tlps.IdentifierTT, "x", nil, 1, 7, 6),
+				newToken(tlps.PlusTT, "+", nil, 1, 9, 8),
+				newToken(tlps.NumberTT, "1", int64(1), 1, 11, 10),
+				newToken(tlps.InterpolationEndTT, "}", nil, 1, 12, 11),
+				newToken(tlps.StringTT, "}}", "}", 1, 13, 12),
+				newToken(tlps.FStringEndTT, "\"", nil, 1, 15, 14),
+				newToken(tlps.EOFTT, "", nil, 1, 16, 15),
+			},
+			code: "f\"a{{{x + 1}}}\"",
+		},
+		{
+			name: "triple-quoted string in block",
+			expected: tlps.TokenList{
+				newToken(tlps.IfTT, "if", nil, 1, 1, 0),
+				newToken(tlps.IdentifierTT, "x", nil, 1, 4, 3),
+				newToken(tlps.ColonTT, ":", nil, 1, 5, 4),
+				newToken(tlps.NewlineTT, "\\n", nil, 1, 6, 5),
+				newToken(tlps.IndentTT, "<indent>", nil, 2, 3, 8),
+				newToken(tlps.StringTT, "\"\"\"\n    a\n  \"\"\"", "  a\n", 2, 3, 8),
+				newToken(tlps.NewlineTT, "\\n", nil, 4, 6, 23),
+				newToken(tlps.IdentifierTT, "r", nil, 5, 3, 26),
+				newToken(tlps.NewlineTT, "\\n", nil, 5, 4, 27),
+				newToken(tlps.DedentTT, "<dedent>", nil, 6, 1, 28),
+				newToken(tlps.StringTT, "r\"\\d\"", "\\d", 6, 1, 28),
+				newToken(tlps.EOFTT, "", nil, 6, 6, 33),
+			},
+			code: "if x:\n  \"\"\"\n    a\n  \"\"\"\n  r\nr\"\\d\"",
+		},
 		{
 			name: "useless newline",
 			expected: tlps.TokenList{
-				tlps.NewToken(tlps.StringTT, "\"hoge\"", "hoge", 3),
-				tlps.NewToken(tlps.NewlineTT, "\\n", nil, 3),
-				tlps.NewToken(tlps.IdentifierTT, "piyo", nil, 5),
-				tlps.NewToken(tlps.NewlineTT, "\\n", nil, 5),
-				tlps.NewToken(tlps.EOFTT, "", nil, 7),
+				newToken(tlps.StringTT, "\"hoge\"", "hoge", 3, 1, 2),
+				newToken(tlps.NewlineTT, "\\n", nil, 3, 7, 8),
+				newToken(tlps.IdentifierTT, "piyo", nil, 5, 1, 10),
+				newToken(tlps.NewlineTT, "\\n", nil, 5, 16, 25),
+				newToken(tlps.EOFTT, "", nil, 7, 14, 50),
 			},
-			code: "\n\n\"hoge\"\n\npiyo // hogehoge\n// piyopiyo\n   // fugafuga",
+			code: "\n\n\"hoge\"\n\npiyo # hogehoge\n# piyopiyo\n   # fugafuga",
 		},
 	}
 	for _, tt := range tests {
@@ -101,3 +194,56 @@ func TestScanner(t *testing.T) {
 		})
 	}
 }
+
+func TestRuntime_InTripleQuote(t *testing.T) {
+	runtime := tlps.NewRuntime()
+
+	var tests = []struct {
+		name     string
+		expected bool
+		code     string
+	}{
+		{
+			name:     "unterminated string",
+			expected: true,
+			code:     "var s = \"\"\"\nhello\n",
+		},
+		{
+			name:     "terminated string",
+			expected: false,
+			code:     "var s = \"\"\"\nhello\n\"\"\"\n",
+		},
+		{
+			name:     "quotes in comment",
+			expected: false,
+			code:     "print(1) # \"\"\"\n",
+		},
+		{
+			name:     "quotes in string",
+			expected: false,
+			code:     "print(\"\\\"\\\"\\\"\")\n",
+		},
+		{
+			name:     "unterminated raw string",
+			expected: true,
+			code:     "var s = r\"\"\"\\\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, runtime.InTripleQuote(tt.code))
+			assert.False(t, runtime.HadError)
+		})
+	}
+}
+
+// newToken makes token scanned from stdin
+func newToken(tt tlps.TokenType, lexeme string, literal interface{}, line, column, offset int) *tlps.Token {
+	token := tlps.NewToken(tt, lexeme, literal, line)
+	token.Column = column
+	token.Offset = offset
+	token.File = "<stdin>"
+
+	return token
+}
@@ -0,0 +1,63 @@
+package tlps
+
+import (
+	"embed"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// stdlib is standard library shipped in the binary.
+// Its files are found as the last entry of search path, e.g., include "std/testing.tlps".
+//
+//go:embed std
+var stdlib embed.FS
+
+// embeddedRoot is pseudo directory of files in stdlib
+const embeddedRoot = "<embedded>"
+
+// FindFile finds file to be included or imported.
+// Relative path is searched from the directory of the current file, directories in SearchPath
+// and standard library in this order.
+func (r *Runtime) FindFile(name string) (string, error) {
+	candidates := []string{name}
+	if !filepath.IsAbs(name) {
+		candidates = []string{filepath.Join(r.BasePath, name)}
+		for _, dir := range r.SearchPath {
+			candidates = append(candidates, filepath.Join(dir, name))
+		}
+		candidates = append(candidates, filepath.Join(embeddedRoot, name))
+	}
+
+	for _, path := range candidates {
+		if embedded, ok := embeddedPath(path); ok {
+			if info, err := fs.Stat(stdlib, embedded); err == nil && !info.IsDir() {
+				return path, nil
+			}
+			continue
+		}
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, nil
+		}
+	}
+
+	return "", errors.New("Can't find file '" + name + "'.")
+}
+
+// readSource reads file found by FindFile
+func readSource(path string) ([]byte, error) {
+	if embedded, ok := embeddedPath(path); ok {
+		return stdlib.ReadFile(embedded)
+	}
+	return os.ReadFile(path)
+}
+
+// embeddedPath returns path in stdlib if path is under embeddedRoot
+func embeddedPath(path string) (string, bool) {
+	if !strings.HasPrefix(path, embeddedRoot+string(filepath.Separator)) {
+		return "", false
+	}
+	return filepath.ToSlash(strings.TrimPrefix(path, embeddedRoot+string(filepath.Separator))), true
+}
@@ -0,0 +1,85 @@
+package tlps_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goropikari/tlps"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuntime_FindFile(t *testing.T) {
+	base := t.TempDir()
+	lib1 := t.TempDir()
+	lib2 := t.TempDir()
+	write := func(path string) {
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		assert.NoError(t, os.WriteFile(path, []byte("var x = 1\n"), 0o644))
+	}
+	write(filepath.Join(base, "local.tlps"))
+	write(filepath.Join(lib1, "util.tlps"))
+	write(filepath.Join(lib2, "util.tlps"))
+	write(filepath.Join(lib2, "net/http.tlps"))
+	write(filepath.Join(lib2, "std/testing.tlps"))
+
+	var tests = []struct {
+		name     string
+		expected string
+		given    string
+	}{
+		{
+			name:     "relative to the current file",
+			expected: filepath.Join(base, "local.tlps"),
+			given:    "local.tlps",
+		},
+		{
+			name:     "first directory in search path wins",
+			expected: filepath.Join(lib1, "util.tlps"),
+			given:    "util.tlps",
+		},
+		{
+			name:     "subdirectory in search path",
+			expected: filepath.Join(lib2, "net/http.tlps"),
+			given:    "net/http.tlps",
+		},
+		{
+			name:     "search path shadows standard library",
+			expected: filepath.Join(lib2, "std/testing.tlps"),
+			given:    "std/testing.tlps",
+		},
+		{
+			name:     "absolute path",
+			expected: filepath.Join(lib1, "util.tlps"),
+			given:    filepath.Join(lib1, "util.tlps"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			r := tlps.NewRuntime()
+			r.BasePath = base
+			r.SearchPath = []string{lib1, lib2}
+			actual, err := r.FindFile(tt.given)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+
+	t.Run("standard library", func(t *testing.T) {
+		r := tlps.NewRuntime()
+		r.BasePath = base
+		actual, err := r.FindFile("std/testing.tlps")
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Join("<embedded>", "std/testing.tlps"), actual)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		r := tlps.NewRuntime()
+		r.BasePath = base
+		r.SearchPath = []string{lib1}
+		_, err := r.FindFile("net/http.tlps")
+		assert.EqualError(t, err, "Can't find file 'net/http.tlps'.")
+	})
+}
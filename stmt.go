@@ -7,14 +7,22 @@ type Stmt interface {
 
 type VisitorStmt interface {
 	visitBlockStmt(*Block) (interface{}, error)
+	visitBreakStmt(*Break) (interface{}, error)
 	visitClassStmt(*Class) (interface{}, error)
+	visitContinueStmt(*Continue) (interface{}, error)
+	visitExceptStmt(*Except) (interface{}, error)
 	visitExpressionStmt(*Expression) (interface{}, error)
+	visitForInStmt(*ForIn) (interface{}, error)
 	visitFunctionStmt(*Function) (interface{}, error)
 	visitIfStmt(*If) (interface{}, error)
+	visitImportStmt(*Import) (interface{}, error)
 	visitIncludeStmt(*Include) (interface{}, error)
+	visitRaiseStmt(*Raise) (interface{}, error)
 	visitReturnStmt(*Return) (interface{}, error)
+	visitTryStmt(*Try) (interface{}, error)
 	visitVarStmt(*Var) (interface{}, error)
 	visitWhileStmt(*While) (interface{}, error)
+	visitYieldStmt(*Yield) (interface{}, error)
 }
 
 type Block struct {
@@ -39,6 +47,26 @@ func (rec *Block) IsType(v interface{}) bool {
 	return false
 }
 
+type Break struct {
+	Keyword *Token
+}
+
+func NewBreak(keyword *Token) Stmt {
+	return &Break{keyword}
+}
+
+func (b *Break) Accept(visitor VisitorStmt) (interface{}, error) {
+	return visitor.visitBreakStmt(b)
+}
+
+func (rec *Break) IsType(v interface{}) bool {
+	switch v.(type) {
+	case *Break:
+		return true
+	}
+	return false
+}
+
 type Class struct {
 	Name       *Token
 	Superclass *Variable
@@ -61,6 +89,49 @@ func (rec *Class) IsType(v interface{}) bool {
 	return false
 }
 
+type Continue struct {
+	Keyword *Token
+}
+
+func NewContinue(keyword *Token) Stmt {
+	return &Continue{keyword}
+}
+
+func (c *Continue) Accept(visitor VisitorStmt) (interface{}, error) {
+	return visitor.visitContinueStmt(c)
+}
+
+func (rec *Continue) IsType(v interface{}) bool {
+	switch v.(type) {
+	case *Continue:
+		return true
+	}
+	return false
+}
+
+type Except struct {
+	Keyword *Token
+	Typ     Expr
+	Name    *Token
+	Body    Stmt
+}
+
+func NewExcept(keyword *Token, typ Expr, name *Token, body Stmt) Stmt {
+	return &Except{keyword, typ, name, body}
+}
+
+func (e *Except) Accept(visitor VisitorStmt) (interface{}, error) {
+	return visitor.visitExceptStmt(e)
+}
+
+func (rec *Except) IsType(v interface{}) bool {
+	switch v.(type) {
+	case *Except:
+		return true
+	}
+	return false
+}
+
 type Expression struct {
 	Expression Expr
 }
@@ -81,14 +152,39 @@ func (rec *Expression) IsType(v interface{}) bool {
 	return false
 }
 
+type ForIn struct {
+	Name     *Token
+	In       *Token
+	Iterable Expr
+	Body     Stmt
+}
+
+func NewForIn(name *Token, in *Token, iterable Expr, body Stmt) Stmt {
+	return &ForIn{name, in, iterable, body}
+}
+
+func (f *ForIn) Accept(visitor VisitorStmt) (interface{}, error) {
+	return visitor.visitForInStmt(f)
+}
+
+func (rec *ForIn) IsType(v interface{}) bool {
+	switch v.(type) {
+	case *ForIn:
+		return true
+	}
+	return false
+}
+
 type Function struct {
-	Name   *Token
-	Params []*Token
-	Body   []Stmt
+	Name     *Token
+	Params   []*Token
+	Defaults []Expr
+	Rest     *Token
+	Body     []Stmt
 }
 
-func NewFunction(name *Token, params []*Token, body []Stmt) Stmt {
-	return &Function{name, params, body}
+func NewFunction(name *Token, params []*Token, defaults []Expr, rest *Token, body []Stmt) Stmt {
+	return &Function{name, params, defaults, rest, body}
 }
 
 func (f *Function) Accept(visitor VisitorStmt) (interface{}, error) {
@@ -125,6 +221,28 @@ func (rec *If) IsType(v interface{}) bool {
 	return false
 }
 
+type Import struct {
+	Keyword *Token
+	Path    *Token
+	Name    *Token
+}
+
+func NewImport(keyword *Token, path *Token, name *Token) Stmt {
+	return &Import{keyword, path, name}
+}
+
+func (i *Import) Accept(visitor VisitorStmt) (interface{}, error) {
+	return visitor.visitImportStmt(i)
+}
+
+func (rec *Import) IsType(v interface{}) bool {
+	switch v.(type) {
+	case *Import:
+		return true
+	}
+	return false
+}
+
 type Include struct {
 	Path *Token
 }
@@ -145,6 +263,27 @@ func (rec *Include) IsType(v interface{}) bool {
 	return false
 }
 
+type Raise struct {
+	Keyword *Token
+	Value   Expr
+}
+
+func NewRaise(keyword *Token, value Expr) Stmt {
+	return &Raise{keyword, value}
+}
+
+func (r *Raise) Accept(visitor VisitorStmt) (interface{}, error) {
+	return visitor.visitRaiseStmt(r)
+}
+
+func (rec *Raise) IsType(v interface{}) bool {
+	switch v.(type) {
+	case *Raise:
+		return true
+	}
+	return false
+}
+
 type Return struct {
 	Keyword *Token
 	Value   Expr
@@ -166,6 +305,29 @@ func (rec *Return) IsType(v interface{}) bool {
 	return false
 }
 
+type Try struct {
+	Keyword       *Token
+	Body          Stmt
+	Handlers      []*Except
+	FinallyBranch Stmt
+}
+
+func NewTry(keyword *Token, body Stmt, handlers []*Except, finallyBranch Stmt) Stmt {
+	return &Try{keyword, body, handlers, finallyBranch}
+}
+
+func (t *Try) Accept(visitor VisitorStmt) (interface{}, error) {
+	return visitor.visitTryStmt(t)
+}
+
+func (rec *Try) IsType(v interface{}) bool {
+	switch v.(type) {
+	case *Try:
+		return true
+	}
+	return false
+}
+
 type Var struct {
 	Name        *Token
 	Initializer Expr
@@ -190,10 +352,11 @@ func (rec *Var) IsType(v interface{}) bool {
 type While struct {
 	Condition Expr
 	Body      Stmt
+	Increment Expr
 }
 
-func NewWhile(condition Expr, body Stmt) Stmt {
-	return &While{condition, body}
+func NewWhile(condition Expr, body Stmt, increment Expr) Stmt {
+	return &While{condition, body, increment}
 }
 
 func (w *While) Accept(visitor VisitorStmt) (interface{}, error) {
@@ -207,3 +370,24 @@ func (rec *While) IsType(v interface{}) bool {
 	}
 	return false
 }
+
+type Yield struct {
+	Keyword *Token
+	Value   Expr
+}
+
+func NewYield(keyword *Token, value Expr) Stmt {
+	return &Yield{keyword, value}
+}
+
+func (y *Yield) Accept(visitor VisitorStmt) (interface{}, error) {
+	return visitor.visitYieldStmt(y)
+}
+
+func (rec *Yield) IsType(v interface{}) bool {
+	switch v.(type) {
+	case *Yield:
+		return true
+	}
+	return false
+}
@@ -1,7 +1,87 @@
 package tlps
 
+import (
+	"errors"
+	"fmt"
+)
+
 // TLPSCallable is interface
 type TLPSCallable interface {
 	Call(*Interpreter, []interface{}) (interface{}, error)
-	Arity() int
+	// Arity returns minimum and maximum number of arguments.
+	// max -1 means that the callable accepts any number of arguments.
+	Arity() (int, int)
+}
+
+// checkArity checks that the number of arguments is acceptable for callable
+func checkArity(callable TLPSCallable, got int) (string, bool) {
+	min, max := callable.Arity()
+	if got >= min && (max == -1 || got <= max) {
+		return "", true
+	}
+
+	switch {
+	case min == max:
+		return fmt.Sprintf("Expected %d arguments but got %d.", min, got), false
+	case max == -1:
+		return fmt.Sprintf("Expected at least %d arguments but got %d.", min, got), false
+	default:
+		return fmt.Sprintf("Expected %d to %d arguments but got %d.", min, max, got), false
+	}
+}
+
+// unsetArgument is placeholder of argument which isn't given.
+// TLPSFunction uses default value for it.
+type unsetArgument struct{}
+
+// bindKeywords places keyword arguments at the positions of corresponding parameters
+func bindKeywords(callable TLPSCallable, arguments []interface{}, keywords []*KeywordArg, values []interface{}) ([]interface{}, error) {
+	var declaration *Function
+	switch c := callable.(type) {
+	case *TLPSFunction:
+		declaration = c.declaration
+	case *TLPSClass:
+		initializer, _ := c.FindMethod("init")
+		if initializer != nil {
+			declaration = initializer.declaration
+		}
+	}
+	if declaration == nil {
+		return nil, errors.New("Can't use keyword arguments for " + stringfy(callable) + ".")
+	}
+
+	bound := make([]interface{}, len(arguments))
+	copy(bound, arguments)
+	for k, keyword := range keywords {
+		position := -1
+		for j, param := range declaration.Params {
+			if param.Lexeme == keyword.Name.Lexeme {
+				position = j
+				break
+			}
+		}
+		if position == -1 {
+			return nil, errors.New("Unexpected keyword argument '" + keyword.Name.Lexeme + "'.")
+		}
+
+		for len(bound) <= position {
+			bound = append(bound, unsetArgument{})
+		}
+		if _, ok := bound[position].(unsetArgument); !ok {
+			return nil, errors.New("Multiple values for argument '" + keyword.Name.Lexeme + "'.")
+		}
+		bound[position] = values[k]
+	}
+
+	min, _ := callable.Arity()
+	for j := 0; j < min; j++ {
+		if j >= len(bound) {
+			return nil, errors.New("Missing argument '" + declaration.Params[j].Lexeme + "'.")
+		}
+		if _, ok := bound[j].(unsetArgument); ok {
+			return nil, errors.New("Missing argument '" + declaration.Params[j].Lexeme + "'.")
+		}
+	}
+
+	return bound, nil
 }
@@ -26,6 +26,17 @@ func (lc *TLPSClass) FindMethod(name string) (*TLPSFunction, error) {
 	return nil, nil
 }
 
+// IsSubclassOf checks that the class is klass or a subclass of klass
+func (lc *TLPSClass) IsSubclassOf(klass *TLPSClass) bool {
+	for c := lc; c != nil; c = c.Superclass {
+		if c == klass {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (lc *TLPSClass) Call(interpreter *Interpreter, arguments []interface{}) (interface{}, error) {
 	instance := NewTLPSInstance(lc)
 	initializer, err := lc.FindMethod("init")
@@ -33,15 +44,19 @@ func (lc *TLPSClass) Call(interpreter *Interpreter, arguments []interface{}) (in
 		return nil, err
 	}
 	if initializer != nil {
-		initializer.Bind(instance).Call(interpreter, arguments)
+		// call frame of initializer is pushed by TLPSFunction.Call
+		_, err := initializer.Bind(instance).Call(interpreter, arguments)
+		if err != nil {
+			return nil, err
+		}
 	}
 	return instance, nil
 }
 
-func (lc *TLPSClass) Arity() int {
+func (lc *TLPSClass) Arity() (int, int) {
 	initializer, _ := lc.FindMethod("init")
 	if initializer == nil {
-		return 0
+		return 0, 0
 	}
 	return initializer.Arity()
 }
@@ -0,0 +1,291 @@
+package tlps
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// TLPSFile is file opened by open()
+type TLPSFile struct {
+	path   string
+	mode   string
+	file   *os.File
+	reader *bufio.Reader
+	closed bool
+}
+
+// openFile opens file with mode "r" (read), "w" (write) or "a" (append)
+func openFile(path string, mode string) (*TLPSFile, error) {
+	var flag int
+	switch mode {
+	case "r":
+		flag = os.O_RDONLY
+	case "w":
+		flag = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	case "a":
+		flag = os.O_WRONLY | os.O_CREATE | os.O_APPEND
+	default:
+		return nil, errors.New("Invalid mode '" + mode + "'. Mode must be 'r', 'w' or 'a'.")
+	}
+
+	file, err := os.OpenFile(path, flag, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	f := &TLPSFile{path: path, mode: mode, file: file}
+	if mode == "r" {
+		f.reader = bufio.NewReader(file)
+	}
+
+	return f, nil
+}
+
+// newStdin returns file object of standard input.
+// It reads from the reader shared with REPL so that buffered input isn't lost.
+func newStdin(reader *bufio.Reader) *TLPSFile {
+	return &TLPSFile{path: "<stdin>", mode: "r", file: os.Stdin, reader: reader}
+}
+
+// Get returns builtin method of file
+func (f *TLPSFile) Get(name *Token) (interface{}, error) {
+	switch name.Lexeme {
+	case "close":
+		return NewBuiltinMethod(name.Lexeme, 0, f.close), nil
+	case "lines":
+		return NewBuiltinMethod(name.Lexeme, 0, f.lines), nil
+	case "read":
+		return NewBuiltinMethod(name.Lexeme, 0, f.read), nil
+	case "readline":
+		return NewBuiltinMethod(name.Lexeme, 0, f.readline), nil
+	case "write":
+		return NewBuiltinMethod(name.Lexeme, 1, f.write), nil
+	}
+
+	return nil, RuntimeError.New(name, "Undefined property '"+name.Lexeme+"'.")
+}
+
+func (f *TLPSFile) String() string {
+	return fmt.Sprintf("<file '%v' mode '%v'>", f.path, f.mode)
+}
+
+func (f *TLPSFile) checkReadable() error {
+	if f.closed {
+		return errors.New("I/O operation on closed file.")
+	}
+	if f.reader == nil {
+		return errors.New("File is not open for reading.")
+	}
+	return nil
+}
+
+func (f *TLPSFile) close(arguments []interface{}) (interface{}, error) {
+	// standard input is never closed because REPL keeps reading it
+	if f.closed || f.file == os.Stdin {
+		return nil, nil
+	}
+	f.closed = true
+	return nil, f.file.Close()
+}
+
+// lines returns the rest of lines without line terminators
+func (f *TLPSFile) lines(arguments []interface{}) (interface{}, error) {
+	if err := f.checkReadable(); err != nil {
+		return nil, err
+	}
+	return readLines(f.reader)
+}
+
+// read returns the rest of file
+func (f *TLPSFile) read(arguments []interface{}) (interface{}, error) {
+	if err := f.checkReadable(); err != nil {
+		return nil, err
+	}
+	b, err := io.ReadAll(f.reader)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// readline returns next line including "\n". It returns "" at the end of file.
+func (f *TLPSFile) readline(arguments []interface{}) (interface{}, error) {
+	if err := f.checkReadable(); err != nil {
+		return nil, err
+	}
+	return readLine(f.reader)
+}
+
+func (f *TLPSFile) write(arguments []interface{}) (interface{}, error) {
+	if f.closed {
+		return nil, errors.New("I/O operation on closed file.")
+	}
+	if f.reader != nil {
+		return nil, errors.New("File is not open for writing.")
+	}
+	s, ok := arguments[0].(string)
+	if !ok {
+		return nil, errors.New("Argument of write must be a string.")
+	}
+	_, err := f.file.WriteString(s)
+	return nil, err
+}
+
+// readLine reads a line including "\n". It returns "" at the end of input.
+func readLine(reader *bufio.Reader) (interface{}, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	return line, nil
+}
+
+// readLines reads the rest of lines without line terminators
+func readLines(reader *bufio.Reader) (interface{}, error) {
+	elements := make([]interface{}, 0)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		if line == "" {
+			break
+		}
+		elements = append(elements, strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r"))
+	}
+
+	return NewTLPSList(elements), nil
+}
+
+// pathArgument checks that argument of file function is a string
+func pathArgument(function string, argument interface{}) (string, error) {
+	path, ok := argument.(string)
+	if !ok {
+		return "", errors.New("Path of " + function + " must be a string.")
+	}
+	return path, nil
+}
+
+// builtinOpen is open(path, mode = "r") which returns file
+func builtinOpen(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	path, err := pathArgument("open", arguments[0])
+	if err != nil {
+		return nil, err
+	}
+	mode := "r"
+	if len(arguments) == 2 {
+		m, ok := arguments[1].(string)
+		if !ok {
+			return nil, errors.New("Mode of open must be a string.")
+		}
+		mode = m
+	}
+
+	return openFile(path, mode)
+}
+
+// builtinReadFile is read_file(path) which returns whole content of file
+func builtinReadFile(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	path, err := pathArgument("read_file", arguments[0])
+	if err != nil {
+		return nil, err
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// builtinWriteFile is write_file(path, content) which replaces content of file
+func builtinWriteFile(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	path, err := pathArgument("write_file", arguments[0])
+	if err != nil {
+		return nil, err
+	}
+	content, ok := arguments[1].(string)
+	if !ok {
+		return nil, errors.New("Content of write_file must be a string.")
+	}
+	return nil, os.WriteFile(path, []byte(content), 0o644)
+}
+
+// builtinExists is exists(path) which checks that file or directory exists
+func builtinExists(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	path, err := pathArgument("exists", arguments[0])
+	if err != nil {
+		return nil, err
+	}
+	_, err = os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return true, nil
+}
+
+// builtinListdir is listdir(path = ".") which returns sorted names of entries in directory
+func builtinListdir(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	path := "."
+	if len(arguments) == 1 {
+		p, err := pathArgument("listdir", arguments[0])
+		if err != nil {
+			return nil, err
+		}
+		path = p
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]interface{}, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return NewTLPSList(names), nil
+}
+
+// builtinMkdir is mkdir(path) which makes directory and its parents as needed
+func builtinMkdir(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	path, err := pathArgument("mkdir", arguments[0])
+	if err != nil {
+		return nil, err
+	}
+	return nil, os.MkdirAll(path, 0o755)
+}
+
+// builtinRemove is remove(path) which removes file or empty directory
+func builtinRemove(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	path, err := pathArgument("remove", arguments[0])
+	if err != nil {
+		return nil, err
+	}
+	return nil, os.Remove(path)
+}
+
+// builtinInput is input(prompt = "") which writes prompt and reads a line from standard input.
+// The line terminator is removed. It returns nil at the end of input.
+func builtinInput(i *Interpreter, arguments []interface{}) (interface{}, error) {
+	if len(arguments) == 1 {
+		if s, ok := arguments[0].(string); ok {
+			fmt.Print(s)
+		} else {
+			fmt.Print(stringfy(arguments[0]))
+		}
+	}
+
+	line, err := i.Runtime.Stdin.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	if line == "" {
+		return nil, nil
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r"), nil
+}
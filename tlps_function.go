@@ -5,22 +5,55 @@ type TLPSFunction struct {
 	declaration   *Function
 	closure       *Environment
 	IsInitializer bool
+	file          string // file where the function is declared
+	className     string // class name if the function is a method
 }
 
 // NewTLPSFunction is constructor of TLPSFunction
-func NewTLPSFunction(declaration *Function, closure *Environment, IsInitializer bool) *TLPSFunction {
+func NewTLPSFunction(declaration *Function, closure *Environment, IsInitializer bool, file string) *TLPSFunction {
 	return &TLPSFunction{
 		declaration:   declaration,
 		closure:       closure,
 		IsInitializer: IsInitializer,
+		file:          file,
 	}
 }
 
 // Call calls the function
 func (lf *TLPSFunction) Call(interpreter *Interpreter, arguments []interface{}) (interface{}, error) {
+	callStack := interpreter.Runtime.CallStack
+	callStack.Push(NewCallFrame(lf.frameName(), lf.file, lf.declaration.Name.Line))
+	defer callStack.Pop()
+
 	environment := NewEnvironment(lf.closure)
-	for i, param := range lf.declaration.Params {
-		environment.Define(param.Lexeme, arguments[i])
+	params := lf.declaration.Params
+	for i, param := range params {
+		if i < len(arguments) {
+			if _, ok := arguments[i].(unsetArgument); !ok {
+				environment.Define(param.Lexeme, arguments[i])
+				continue
+			}
+		}
+
+		// default value is evaluated at every call after preceding parameters are bound
+		value, err := interpreter.evaluateIn(lf.declaration.Defaults[i], environment)
+		if err != nil {
+			interpreter.attachTraceback(err)
+			return nil, err
+		}
+		environment.Define(param.Lexeme, value)
+	}
+	if rest := lf.declaration.Rest; rest != nil && len(arguments) > len(params) {
+		extra := make([]interface{}, len(arguments)-len(params))
+		copy(extra, arguments[len(params):])
+		environment.Define(rest.Lexeme, NewTLPSList(extra))
+	} else if rest != nil {
+		environment.Define(rest.Lexeme, NewTLPSList([]interface{}{}))
+	}
+
+	if interpreter.Runtime.Generators[lf.declaration] {
+		// body is executed when the generator is iterated
+		return NewGenerator(lf.frameName(), lf.file, lf.declaration, environment), nil
 	}
 
 	_, err := interpreter.executeBlock(lf.declaration.Body, environment)
@@ -33,6 +66,7 @@ func (lf *TLPSFunction) Call(interpreter *Interpreter, arguments []interface{})
 			}
 			return v.(*ReturnValue).Value, nil
 		default:
+			interpreter.attachTraceback(err)
 			return nil, err
 		}
 	}
@@ -45,14 +79,32 @@ func (lf *TLPSFunction) Call(interpreter *Interpreter, arguments []interface{})
 }
 
 // Arity returns arity of function
-func (lf *TLPSFunction) Arity() int {
-	return len(lf.declaration.Params)
+func (lf *TLPSFunction) Arity() (int, int) {
+	n := len(lf.declaration.Params)
+	min := 0
+	for min < n && (len(lf.declaration.Defaults) <= min || lf.declaration.Defaults[min] == nil) {
+		min++
+	}
+	if lf.declaration.Rest != nil {
+		return min, -1
+	}
+	return min, n
 }
 
 func (lc *TLPSFunction) Bind(instance *TLPSInstance) *TLPSFunction {
 	environment := NewEnvironment(lc.closure)
 	environment.Define("this", instance)
-	return NewTLPSFunction(lc.declaration, environment, lc.IsInitializer)
+	function := NewTLPSFunction(lc.declaration, environment, lc.IsInitializer, lc.file)
+	function.className = lc.className
+	return function
+}
+
+// frameName returns name shown in traceback
+func (lf *TLPSFunction) frameName() string {
+	if lf.className != "" {
+		return lf.className + "." + lf.declaration.Name.Lexeme
+	}
+	return lf.declaration.Name.Lexeme
 }
 
 func (lf *TLPSFunction) String() string {
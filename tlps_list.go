@@ -0,0 +1,222 @@
+package tlps
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+)
+
+// TLPSList is struct of list
+type TLPSList struct {
+	Elements []interface{}
+}
+
+// NewTLPSList is constructor of TLPSList
+func NewTLPSList(elements []interface{}) *TLPSList {
+	return &TLPSList{
+		Elements: elements,
+	}
+}
+
+// Len returns the number of elements
+func (l *TLPSList) Len() int {
+	return len(l.Elements)
+}
+
+// GetIndex returns the element at given index.
+// Negative index counts from the end of the list.
+func (l *TLPSList) GetIndex(bracket *Token, index interface{}) (interface{}, error) {
+	idx, err := l.position(bracket, index)
+	if err != nil {
+		return nil, err
+	}
+
+	return l.Elements[idx], nil
+}
+
+// SetIndex replaces the element at given index.
+func (l *TLPSList) SetIndex(bracket *Token, index interface{}, value interface{}) error {
+	idx, err := l.position(bracket, index)
+	if err != nil {
+		return err
+	}
+	l.Elements[idx] = value
+
+	return nil
+}
+
+// Slice returns new list which has elements from start to stop
+func (l *TLPSList) Slice(bracket *Token, start interface{}, stop interface{}) (interface{}, error) {
+	lo, hi, err := sliceBounds(bracket, l.Len(), start, stop)
+	if err != nil {
+		return nil, err
+	}
+	elements := make([]interface{}, hi-lo)
+	copy(elements, l.Elements[lo:hi])
+
+	return NewTLPSList(elements), nil
+}
+
+// sliceBounds converts start and stop of slice to indices of sequence like python.
+// nil means the beginning or the end, and negative index counts from the end.
+// Indices out of range are clamped.
+func sliceBounds(bracket *Token, length int, start interface{}, stop interface{}) (int, int, error) {
+	bound := func(v interface{}, omitted int) (int, error) {
+		if v == nil {
+			return omitted, nil
+		}
+		if n, ok := v.(*big.Int); ok {
+			if n.Sign() < 0 {
+				return 0, nil
+			}
+			return length, nil
+		}
+		idx, ok := toInt(v)
+		if !ok {
+			return 0, RuntimeError.New(bracket, "Slice indices must be integers.")
+		}
+		if idx < 0 {
+			idx += length
+		}
+		if idx < 0 {
+			return 0, nil
+		}
+		if idx > length {
+			return length, nil
+		}
+		return idx, nil
+	}
+
+	lo, err := bound(start, 0)
+	if err != nil {
+		return 0, 0, err
+	}
+	hi, err := bound(stop, length)
+	if err != nil {
+		return 0, 0, err
+	}
+	if hi < lo {
+		hi = lo
+	}
+
+	return lo, hi, nil
+}
+
+func (l *TLPSList) position(bracket *Token, index interface{}) (int, error) {
+	idx, ok := toInt(index)
+	if !ok {
+		return 0, RuntimeError.New(bracket, "List indices must be integers.")
+	}
+	if idx < 0 {
+		idx += l.Len()
+	}
+	if idx < 0 || idx >= l.Len() {
+		return 0, RuntimeError.New(bracket, "List index out of range.")
+	}
+
+	return idx, nil
+}
+
+// Get returns builtin method of list
+func (l *TLPSList) Get(name *Token) (interface{}, error) {
+	switch name.Lexeme {
+	case "append":
+		return NewBuiltinMethod(name.Lexeme, 1, l.append), nil
+	case "extend":
+		return NewBuiltinMethod(name.Lexeme, 1, l.extend), nil
+	case "insert":
+		return NewBuiltinMethod(name.Lexeme, 2, l.insert), nil
+	case "len":
+		return NewBuiltinMethod(name.Lexeme, 0, l.len), nil
+	case "pop":
+		return NewBuiltinMethod(name.Lexeme, -1, l.pop), nil
+	}
+
+	return nil, RuntimeError.New(name, "Undefined property '"+name.Lexeme+"'.")
+}
+
+func (l *TLPSList) append(arguments []interface{}) (interface{}, error) {
+	l.Elements = append(l.Elements, arguments[0])
+	return nil, nil
+}
+
+func (l *TLPSList) extend(arguments []interface{}) (interface{}, error) {
+	other, ok := arguments[0].(*TLPSList)
+	if !ok {
+		return nil, errors.New("Argument of extend must be a list.")
+	}
+	// copy elements first so that xs.extend(xs) works
+	elements := make([]interface{}, other.Len())
+	copy(elements, other.Elements)
+	l.Elements = append(l.Elements, elements...)
+
+	return nil, nil
+}
+
+// insert inserts an element before given index like python.
+// Index out of range is clamped to the both ends of the list.
+func (l *TLPSList) insert(arguments []interface{}) (interface{}, error) {
+	idx, ok := toInt(arguments[0])
+	if !ok {
+		return nil, errors.New("List indices must be integers.")
+	}
+	if idx < 0 {
+		idx += l.Len()
+	}
+	if idx < 0 {
+		idx = 0
+	}
+	if idx > l.Len() {
+		idx = l.Len()
+	}
+
+	l.Elements = append(l.Elements, nil)
+	copy(l.Elements[idx+1:], l.Elements[idx:])
+	l.Elements[idx] = arguments[1]
+
+	return nil, nil
+}
+
+func (l *TLPSList) len(arguments []interface{}) (interface{}, error) {
+	return int64(l.Len()), nil
+}
+
+// pop removes the element at given index and returns it.
+// If index is omitted, it removes the last element.
+func (l *TLPSList) pop(arguments []interface{}) (interface{}, error) {
+	if len(arguments) > 1 {
+		return nil, errors.New("pop expected at most 1 argument.")
+	}
+	if l.Len() == 0 {
+		return nil, errors.New("pop from empty list.")
+	}
+
+	idx := l.Len() - 1
+	if len(arguments) == 1 {
+		var ok bool
+		idx, ok = toInt(arguments[0])
+		if !ok {
+			return nil, errors.New("List indices must be integers.")
+		}
+		if idx < 0 {
+			idx += l.Len()
+		}
+		if idx < 0 || idx >= l.Len() {
+			return nil, errors.New("pop index out of range.")
+		}
+	}
+
+	v := l.Elements[idx]
+	l.Elements = append(l.Elements[:idx], l.Elements[idx+1:]...)
+
+	return v, nil
+}
+
+func (l *TLPSList) String() string {
+	elements := make([]string, 0, l.Len())
+	for _, v := range l.Elements {
+		elements = append(elements, repr(v))
+	}
+
+	return "[" + strings.Join(elements, ", ") + "]"
+}
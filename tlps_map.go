@@ -0,0 +1,211 @@
+package tlps
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// TLPSMap is struct of hash map.
+// It remembers insertion order of keys.
+// values is indexed by hashKey of key so that equal numbers such as 1 and 1.0 are the same key.
+type TLPSMap struct {
+	keys   []interface{}
+	values map[interface{}]interface{}
+}
+
+// NewTLPSMap is constructor of TLPSMap
+func NewTLPSMap() *TLPSMap {
+	return &TLPSMap{
+		keys:   make([]interface{}, 0),
+		values: make(map[interface{}]interface{}),
+	}
+}
+
+// Len returns the number of entries
+func (m *TLPSMap) Len() int {
+	return len(m.keys)
+}
+
+// Keys returns keys in insertion order
+func (m *TLPSMap) Keys() []interface{} {
+	return m.keys
+}
+
+// Lookup returns value associated with key
+func (m *TLPSMap) Lookup(key interface{}) (interface{}, bool) {
+	if !isHashable(key) {
+		return nil, false
+	}
+	v, ok := m.values[hashKey(key)]
+	return v, ok
+}
+
+// Put associates value with key
+func (m *TLPSMap) Put(key interface{}, value interface{}) error {
+	if !isHashable(key) {
+		return errors.New("Unhashable type: " + typeName(key) + ".")
+	}
+	hk := hashKey(key)
+	if _, ok := m.values[hk]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[hk] = value
+
+	return nil
+}
+
+// Delete removes key from map
+func (m *TLPSMap) Delete(key interface{}) bool {
+	if !isHashable(key) {
+		return false
+	}
+	hk := hashKey(key)
+	if _, ok := m.values[hk]; !ok {
+		return false
+	}
+
+	delete(m.values, hk)
+	for i, k := range m.keys {
+		if hashKey(k) == hk {
+			m.keys = append(m.keys[:i], m.keys[i+1:]...)
+			break
+		}
+	}
+
+	return true
+}
+
+// GetIndex returns value associated with key
+func (m *TLPSMap) GetIndex(bracket *Token, key interface{}) (interface{}, error) {
+	if !isHashable(key) {
+		return nil, RuntimeError.New(bracket, "Unhashable type: "+typeName(key)+".")
+	}
+	if v, ok := m.values[hashKey(key)]; ok {
+		return v, nil
+	}
+
+	return nil, RuntimeError.New(bracket, "Key not found: "+repr(key)+".")
+}
+
+// SetIndex associates value with key
+func (m *TLPSMap) SetIndex(bracket *Token, key interface{}, value interface{}) error {
+	err := m.Put(key, value)
+	if err != nil {
+		return RuntimeError.New(bracket, err.Error())
+	}
+
+	return nil
+}
+
+// Get returns builtin method of map
+func (m *TLPSMap) Get(name *Token) (interface{}, error) {
+	switch name.Lexeme {
+	case "delete":
+		return NewBuiltinMethod(name.Lexeme, 1, m.delete), nil
+	case "has":
+		return NewBuiltinMethod(name.Lexeme, 1, m.has), nil
+	case "items":
+		return NewBuiltinMethod(name.Lexeme, 0, m.items), nil
+	case "keys":
+		return NewBuiltinMethod(name.Lexeme, 0, m.keysMethod), nil
+	case "len":
+		return NewBuiltinMethod(name.Lexeme, 0, m.len), nil
+	case "values":
+		return NewBuiltinMethod(name.Lexeme, 0, m.valuesMethod), nil
+	}
+
+	return nil, RuntimeError.New(name, "Undefined property '"+name.Lexeme+"'.")
+}
+
+func (m *TLPSMap) delete(arguments []interface{}) (interface{}, error) {
+	if !m.Delete(arguments[0]) {
+		return nil, errors.New("Key not found: " + repr(arguments[0]) + ".")
+	}
+	return nil, nil
+}
+
+func (m *TLPSMap) has(arguments []interface{}) (interface{}, error) {
+	_, ok := m.Lookup(arguments[0])
+	return ok, nil
+}
+
+// items returns list of [key, value] pairs
+func (m *TLPSMap) items(arguments []interface{}) (interface{}, error) {
+	items := make([]interface{}, 0, m.Len())
+	for _, k := range m.keys {
+		items = append(items, NewTLPSList([]interface{}{k, m.values[hashKey(k)]}))
+	}
+	return NewTLPSList(items), nil
+}
+
+func (m *TLPSMap) keysMethod(arguments []interface{}) (interface{}, error) {
+	keys := make([]interface{}, m.Len())
+	copy(keys, m.keys)
+	return NewTLPSList(keys), nil
+}
+
+func (m *TLPSMap) len(arguments []interface{}) (interface{}, error) {
+	return int64(m.Len()), nil
+}
+
+func (m *TLPSMap) valuesMethod(arguments []interface{}) (interface{}, error) {
+	values := make([]interface{}, 0, m.Len())
+	for _, k := range m.keys {
+		values = append(values, m.values[hashKey(k)])
+	}
+	return NewTLPSList(values), nil
+}
+
+func (m *TLPSMap) String() string {
+	entries := make([]string, 0, m.Len())
+	for _, k := range m.keys {
+		entries = append(entries, repr(k)+": "+repr(m.values[hashKey(k)]))
+	}
+
+	return "{" + strings.Join(entries, ", ") + "}"
+}
+
+// isHashable checks that value can be used as a key of map
+func isHashable(v interface{}) bool {
+	switch v.(type) {
+	case nil, bool, int64, *big.Int, float64, *Decimal, string:
+		return true
+	}
+	return false
+}
+
+// bigKey is hash key of big integer
+type bigKey string
+
+// decimalKey is hash key of decimal which can't be represented by integer or float
+type decimalKey string
+
+// hashKey returns the value used as key of go map.
+// Integral float is converted to integer since 1 == 1.0.
+func hashKey(v interface{}) interface{} {
+	switch n := v.(type) {
+	case float64:
+		if n == math.Trunc(n) && n >= math.MinInt64 && n < math.MaxInt64 {
+			return int64(n)
+		}
+		if n == math.Trunc(n) && !math.IsInf(n, 0) {
+			b, _ := big.NewFloat(n).Int(nil)
+			return bigKey(b.String())
+		}
+	case *big.Int:
+		return bigKey(n.String())
+	case *Decimal:
+		// decimal equal to integer or float shares the key with it
+		r := n.Rat()
+		if r.IsInt() {
+			return hashKey(normalizeInt(r.Num()))
+		}
+		if f, exact := r.Float64(); exact {
+			return f
+		}
+		return decimalKey(r.String())
+	}
+	return v
+}
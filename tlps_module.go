@@ -0,0 +1,37 @@
+package tlps
+
+import "strings"
+
+// TLPSModule is namespace made by import.
+// Top-level definitions of the module are accessed as its properties.
+// Names beginning with underscore are private to the module.
+type TLPSModule struct {
+	name        string
+	path        string
+	environment *Environment
+}
+
+// NewTLPSModule is constructor of TLPSModule
+func NewTLPSModule(name string, path string, environment *Environment) *TLPSModule {
+	return &TLPSModule{
+		name:        name,
+		path:        path,
+		environment: environment,
+	}
+}
+
+// Get returns top-level definition of the module
+func (m *TLPSModule) Get(name *Token) (interface{}, error) {
+	if strings.HasPrefix(name.Lexeme, "_") {
+		return nil, RuntimeError.New(name, "Can't access private name '"+name.Lexeme+"' of module '"+m.name+"'.")
+	}
+	if v, ok := m.environment.Values[name.Lexeme]; ok {
+		return v, nil
+	}
+
+	return nil, RuntimeError.New(name, "Module '"+m.name+"' has no attribute '"+name.Lexeme+"'.")
+}
+
+func (m *TLPSModule) String() string {
+	return "<module " + m.name + ">"
+}
@@ -0,0 +1,268 @@
+package tlps
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TLPSString provides indexing and builtin methods of string.
+// Strings are held as go string, and converted to TLPSString only when they are used.
+// Indices count runes, not bytes.
+type TLPSString string
+
+// Len returns the number of runes
+func (s TLPSString) Len() int {
+	return utf8.RuneCountInString(string(s))
+}
+
+// GetIndex returns the character at given index as string.
+// Negative index counts from the end of the string.
+func (s TLPSString) GetIndex(bracket *Token, index interface{}) (interface{}, error) {
+	runes := []rune(string(s))
+	idx, ok := toInt(index)
+	if !ok {
+		return nil, RuntimeError.New(bracket, "String indices must be integers.")
+	}
+	if idx < 0 {
+		idx += len(runes)
+	}
+	if idx < 0 || idx >= len(runes) {
+		return nil, RuntimeError.New(bracket, "String index out of range.")
+	}
+
+	return string(runes[idx]), nil
+}
+
+// Slice returns substring from start to stop
+func (s TLPSString) Slice(bracket *Token, start interface{}, stop interface{}) (interface{}, error) {
+	runes := []rune(string(s))
+	lo, hi, err := sliceBounds(bracket, len(runes), start, stop)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(runes[lo:hi]), nil
+}
+
+// Get returns builtin method of string
+func (s TLPSString) Get(name *Token) (interface{}, error) {
+	switch name.Lexeme {
+	case "endswith":
+		return NewBuiltinMethod(name.Lexeme, 1, s.endswith), nil
+	case "find":
+		return NewBuiltinMethod(name.Lexeme, 1, s.find), nil
+	case "format":
+		return NewBuiltinMethod(name.Lexeme, -1, s.format), nil
+	case "join":
+		return NewBuiltinMethod(name.Lexeme, 1, s.join), nil
+	case "len":
+		return NewBuiltinMethod(name.Lexeme, 0, s.len), nil
+	case "lower":
+		return NewBuiltinMethod(name.Lexeme, 0, s.lower), nil
+	case "lstrip":
+		return NewBuiltinMethod(name.Lexeme, -1, s.lstrip), nil
+	case "replace":
+		return NewBuiltinMethod(name.Lexeme, 2, s.replace), nil
+	case "rstrip":
+		return NewBuiltinMethod(name.Lexeme, -1, s.rstrip), nil
+	case "split":
+		return NewBuiltinMethod(name.Lexeme, -1, s.split), nil
+	case "startswith":
+		return NewBuiltinMethod(name.Lexeme, 1, s.startswith), nil
+	case "strip":
+		return NewBuiltinMethod(name.Lexeme, -1, s.strip), nil
+	case "upper":
+		return NewBuiltinMethod(name.Lexeme, 0, s.upper), nil
+	}
+
+	return nil, RuntimeError.New(name, "Undefined property '"+name.Lexeme+"'.")
+}
+
+// stringArguments checks that all arguments are string
+func stringArguments(method string, arguments []interface{}) ([]string, error) {
+	strs := make([]string, 0, len(arguments))
+	for _, v := range arguments {
+		str, ok := v.(string)
+		if !ok {
+			return nil, errors.New("Argument of " + method + " must be a string.")
+		}
+		strs = append(strs, str)
+	}
+
+	return strs, nil
+}
+
+func (s TLPSString) endswith(arguments []interface{}) (interface{}, error) {
+	args, err := stringArguments("endswith", arguments)
+	if err != nil {
+		return nil, err
+	}
+	return strings.HasSuffix(string(s), args[0]), nil
+}
+
+// find returns the index of the first occurrence of substring, or -1 if it isn't found.
+func (s TLPSString) find(arguments []interface{}) (interface{}, error) {
+	args, err := stringArguments("find", arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	idx := strings.Index(string(s), args[0])
+	if idx < 0 {
+		return int64(-1), nil
+	}
+	return int64(utf8.RuneCountInString(string(s)[:idx])), nil
+}
+
+// format replaces "{}" and "{n}" with stringfied arguments.
+// "{{" and "}}" are replaced with "{" and "}".
+func (s TLPSString) format(arguments []interface{}) (interface{}, error) {
+	var b strings.Builder
+	next := 0
+	str := string(s)
+	for k := 0; k < len(str); k++ {
+		switch c := str[k]; {
+		case c == '{' && k+1 < len(str) && str[k+1] == '{':
+			b.WriteByte('{')
+			k++
+		case c == '}' && k+1 < len(str) && str[k+1] == '}':
+			b.WriteByte('}')
+			k++
+		case c == '{':
+			end := strings.IndexByte(str[k:], '}')
+			if end < 0 {
+				return nil, errors.New("Single '{' encountered in format string.")
+			}
+			field := str[k+1 : k+end]
+			idx := next
+			if field != "" {
+				n, err := strconv.Atoi(field)
+				if err != nil || n < 0 {
+					return nil, errors.New("Invalid replacement field '{" + field + "}'.")
+				}
+				idx = n
+			}
+			if idx >= len(arguments) {
+				return nil, errors.New("Replacement index " + strconv.Itoa(idx) + " out of range.")
+			}
+			if v, ok := arguments[idx].(string); ok {
+				b.WriteString(v)
+			} else {
+				b.WriteString(stringfy(arguments[idx]))
+			}
+			next = idx + 1
+			k += end
+		case c == '}':
+			return nil, errors.New("Single '}' encountered in format string.")
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return b.String(), nil
+}
+
+// join concatenates strings in the list with the string as separator
+func (s TLPSString) join(arguments []interface{}) (interface{}, error) {
+	list, ok := arguments[0].(*TLPSList)
+	if !ok {
+		return nil, errors.New("Argument of join must be a list.")
+	}
+	strs, err := stringArguments("join", list.Elements)
+	if err != nil {
+		return nil, errors.New("Elements of join must be strings.")
+	}
+
+	return strings.Join(strs, string(s)), nil
+}
+
+func (s TLPSString) len(arguments []interface{}) (interface{}, error) {
+	return int64(s.Len()), nil
+}
+
+func (s TLPSString) lower(arguments []interface{}) (interface{}, error) {
+	return strings.ToLower(string(s)), nil
+}
+
+func (s TLPSString) replace(arguments []interface{}) (interface{}, error) {
+	args, err := stringArguments("replace", arguments)
+	if err != nil {
+		return nil, err
+	}
+	return strings.ReplaceAll(string(s), args[0], args[1]), nil
+}
+
+// split splits the string by separator.
+// If separator is omitted, the string is split by runs of whitespace.
+func (s TLPSString) split(arguments []interface{}) (interface{}, error) {
+	if len(arguments) > 1 {
+		return nil, errors.New("split expected at most 1 argument.")
+	}
+	args, err := stringArguments("split", arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	var strs []string
+	if len(args) == 0 {
+		strs = strings.Fields(string(s))
+	} else if args[0] == "" {
+		return nil, errors.New("Empty separator.")
+	} else {
+		strs = strings.Split(string(s), args[0])
+	}
+
+	elements := make([]interface{}, 0, len(strs))
+	for _, v := range strs {
+		elements = append(elements, v)
+	}
+	return NewTLPSList(elements), nil
+}
+
+func (s TLPSString) startswith(arguments []interface{}) (interface{}, error) {
+	args, err := stringArguments("startswith", arguments)
+	if err != nil {
+		return nil, err
+	}
+	return strings.HasPrefix(string(s), args[0]), nil
+}
+
+// strip removes leading and trailing characters.
+// If characters are omitted, whitespaces are removed.
+func (s TLPSString) strip(arguments []interface{}) (interface{}, error) {
+	return s.trim("strip", arguments, strings.TrimSpace, strings.Trim)
+}
+
+func (s TLPSString) lstrip(arguments []interface{}) (interface{}, error) {
+	return s.trim("lstrip", arguments, func(str string) string {
+		return strings.TrimLeftFunc(str, unicode.IsSpace)
+	}, strings.TrimLeft)
+}
+
+func (s TLPSString) rstrip(arguments []interface{}) (interface{}, error) {
+	return s.trim("rstrip", arguments, func(str string) string {
+		return strings.TrimRightFunc(str, unicode.IsSpace)
+	}, strings.TrimRight)
+}
+
+func (s TLPSString) trim(method string, arguments []interface{}, space func(string) string, cutset func(string, string) string) (interface{}, error) {
+	if len(arguments) > 1 {
+		return nil, errors.New(method + " expected at most 1 argument.")
+	}
+	args, err := stringArguments(method, arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(args) == 0 {
+		return space(string(s)), nil
+	}
+	return cutset(string(s), args[0]), nil
+}
+
+func (s TLPSString) upper(arguments []interface{}) (interface{}, error) {
+	return strings.ToUpper(string(s)), nil
+}
@@ -11,6 +11,8 @@ const (
 	RightParenTT
 	LeftBraceTT
 	RightBraceTT
+	LeftBracketTT
+	RightBracketTT
 	CommaTT
 	DotTT
 	MinusTT
@@ -20,6 +22,11 @@ const (
 	ColonTT
 	SlashTT
 	StarTT
+	PercentTT
+	AmpersandTT
+	PipeTT
+	CaretTT
+	TildeTT
 
 	// One or two chacacter tokens
 	BangTT
@@ -29,33 +36,57 @@ const (
 	EqualEqualTT
 	GreaterTT
 	GreaterEqualTT
+	GreaterGreaterTT
 	LessTT
 	LessEqualTT
+	LessLessTT
+	SlashSlashTT
+	StarStarTT
 
 	// Literal
 	IdentifierTT
 	StringTT
 	NumberTT
 
+	// f-string
+	FStringStartTT       // f"
+	FStringEndTT         // closing "
+	InterpolationStartTT // { of embedded expression
+	InterpolationEndTT   // } of embedded expression
+
 	// keywords
 	AndTT
+	AsTT
+	BreakTT
 	ClassTT
+	ContinueTT
 	ElseTT
 	ElseifTT
+	ExceptTT
 	FalseTT
+	FinallyTT
 	FunTT
 	ForTT
 	IfTT
+	ImportTT
+	InTT
 	IncludeTT
 	NilTT
 	OrTT
 	PassTT
+	RaiseTT
 	ReturnTT
 	SuperTT
 	ThisTT
 	TrueTT
+	TryTT
 	VarTT
 	WhileTT
+	YieldTT
+
+	// Indentation
+	IndentTT
+	DedentTT
 
 	EOFTT
 )
@@ -66,6 +97,9 @@ type Token struct {
 	Lexeme  string
 	Literal interface{}
 	Line    int
+	Column  int    // 1-based column in runes. 0 means unknown
+	Offset  int    // byte offset from the beginning of source
+	File    string // file name of source
 }
 
 // TokenList is slice of Token
@@ -17,11 +17,22 @@ func main() {
 		"Assign : name *Token, value Expr",
 		"Binary : left Expr, operator *Token, right Expr",
 		"Call : callee Expr, paren *Token, arguments []Expr",
+		"Comparison : operands []Expr, operators []*Token",
+		"Conditional : condition Expr, thenBranch Expr, elseBranch Expr",
 		"Get : object Expr, name *Token",
 		"Grouping : expression Expr",
+		"Index : object Expr, bracket *Token, index Expr",
+		"Interpolation : fstring *Token, parts []Expr",
+		"KeywordArg : name *Token, value Expr",
+		"Lambda : function *Function",
+		"List : bracket *Token, elements []Expr",
 		"Literal : value interface{}",
 		"Logical : left Expr, operator *Token, right Expr",
+		"Map : brace *Token, keys []Expr, values []Expr",
 		"Set : object Expr, name *Token, value Expr",
+		"SetIndex : object Expr, bracket *Token, index Expr, value Expr",
+		"Slice : object Expr, bracket *Token, start Expr, stop Expr",
+		"Spread : star *Token, value Expr",
 		"Super : keyword *Token, method *Token",
 		"This : keyword *Token",
 		"Unary : operator *Token, right Expr",
@@ -30,14 +41,22 @@ func main() {
 
 	defineAst(outputDir, "Stmt", []string{
 		"Block : statements []Stmt, keyword *Token, typ BlockType",
+		"Break : keyword *Token",
 		"Class : name *Token, superclass *Variable, methods []*Function",
+		"Continue : keyword *Token",
+		"Except : keyword *Token, typ Expr, name *Token, body Stmt",
 		"Expression: expression Expr",
-		"Function : name *Token, params []*Token, body []Stmt",
+		"ForIn : name *Token, in *Token, iterable Expr, body Stmt",
+		"Function : name *Token, params []*Token, defaults []Expr, rest *Token, body []Stmt",
 		"If : condition Expr, thenBranch Stmt, elseBranch Stmt",
+		"Import : keyword *Token, path *Token, name *Token",
 		"Include : path *Token",
+		"Raise : keyword *Token, value Expr",
 		"Return : keyword *Token, value Expr",
+		"Try : keyword *Token, body Stmt, handlers []*Except, finallyBranch Stmt",
 		"Var : name *Token, initializer Expr",
-		"While : condition Expr, body Stmt",
+		"While : condition Expr, body Stmt, increment Expr",
+		"Yield : keyword *Token, value Expr",
 	})
 }
 